@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/handler"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/local"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/postgresql"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/server"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/service"
@@ -13,6 +14,8 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"context"
 	"time"
@@ -46,6 +49,27 @@ func main() {
 	personService := initServices(db, appLogger)
 	router := http.NewRouter(personService, appLogger)
 
+	// Фоновое обновление устаревших данных; ENRICH_TTL не задан — выключено
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if ttl := getEnvDuration("ENRICH_TTL", 0); ttl > 0 {
+		scheduler := service.NewRefreshScheduler(personService, appLogger, service.RefreshConfig{
+			TTL:       ttl,
+			Interval:  getEnvDuration("ENRICH_INTERVAL", time.Minute),
+			BatchSize: getEnvInt("ENRICH_BATCH_SIZE", 50),
+		})
+		go scheduler.Run(ctx)
+	}
+
+	// Окончательное удаление мягко удалённых записей; PURGE_RETENTION не задан — выключено
+	if retention := getEnvDuration("PURGE_RETENTION", 0); retention > 0 {
+		purger := service.NewPurgeScheduler(personService, appLogger, service.PurgeConfig{
+			Retention: retention,
+			Interval:  getEnvDuration("PURGE_INTERVAL", time.Hour),
+		})
+		go purger.Run(ctx)
+	}
+
 	server := server.NewServer(os.Getenv("APP_Port"), router, appLogger)
 	server.Start()
 
@@ -108,10 +132,96 @@ func initDB(logger logger.Logger) (*sql.DB, error) {
 
 func initServices(db *sql.DB, logger logger.Logger) *service.PersonService {
 	personRepo := postgresql.NewPersonRepository(db)
-	apiClient := api.NewAPIClient(
-		os.Getenv("AGIFY_URL"),
-		os.Getenv("GENDERIZE_URL"),
-		os.Getenv("NATIONALIZE_URL"),
+	nationalityStrategy, err := api.NewNationalityStrategy(
+		os.Getenv("NATIONALITY_STRATEGY"),
+		int64(getEnvInt("NATIONALITY_SEED", 0)),
+		getEnvFloat("NATIONALITY_MIN_PROBABILITY", 0),
 	)
-	return service.NewPersonService(personRepo, apiClient)
+	if err != nil {
+		logger.Fatal("Invalid nationality strategy", err)
+	}
+	apiClient := api.NewAPIClient(api.Config{
+		Agify:               providerConfig("AGIFY"),
+		Genderize:           providerConfig("GENDERIZE"),
+		Nationalize:         providerConfig("NATIONALIZE"),
+		QuotaReserve:        getEnvInt("API_QUOTA_RESERVE", 0),
+		NationalityStrategy: nationalityStrategy,
+	})
+	// Локальный набор данных нужен для офлайн-обогащения или как резерв
+	enrichmentSource := os.Getenv("ENRICHMENT_SOURCE")
+	var dataset *local.Dataset
+	if datasetPath := os.Getenv("LOCAL_DATASET_PATH"); datasetPath != "" || (enrichmentSource != "" && enrichmentSource != service.EnrichmentAPI) {
+		dataset, err = local.NewDataset(datasetPath, nationalityStrategy)
+		if err != nil {
+			logger.Fatal("Failed to load local dataset", err)
+		}
+		logger.Info("Local dataset loaded", "entries", dataset.Size())
+	}
+
+	personService, err := service.NewPersonService(personRepo, apiClient, service.Config{
+		TranslitScheme:    os.Getenv("TRANSLIT_SCHEME"),
+		MixedScriptPolicy: os.Getenv("MIXED_SCRIPT_POLICY"),
+		GenderPrecedence:  os.Getenv("GENDER_PRECEDENCE"),
+		EnrichmentSource:  enrichmentSource,
+		Dataset:           dataset,
+		PreviewCacheTTL:   getEnvDuration("PREVIEW_CACHE_TTL", 10*time.Minute),
+		PreviewCacheSize:  getEnvInt("PREVIEW_CACHE_SIZE", 1000),
+		PhoneCountryCode:  os.Getenv("PHONE_COUNTRY_CODE"),
+	})
+	if err != nil {
+		logger.Fatal("Invalid service configuration", err)
+	}
+	return personService
+}
+
+// providerConfig собирает настройки провайдера из переменных окружения с префиксом prefix
+func providerConfig(prefix string) api.ProviderConfig {
+	return api.ProviderConfig{
+		URL:       os.Getenv(prefix + "_URL"),
+		Script:    os.Getenv(prefix + "_SCRIPT"),
+		APIKey:    api.Secret(readSecret(prefix + "_API_KEY")),
+		RateLimit: getEnvFloat(prefix+"_RATE_LIMIT", 0),
+		Burst:     getEnvInt(prefix+"_RATE_BURST", 1),
+	}
+}
+
+// readSecret читает секрет из переменной key или из файла, указанного в key_FILE
+// (например, docker/kubernetes secret)
+func readSecret(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read secret file for %s: %v", key, err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
 }
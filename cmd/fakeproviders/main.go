@@ -0,0 +1,390 @@
+// Команда fakeproviders поднимает локальную замену agify, genderize и nationalize
+// с теми же форматами ответов — для интеграционных тестов и демо без сети и без
+// расхода квоты. Поддерживает пакетные запросы name[], внедрение ошибок и задержек
+// и заранее заданные ответы из файла фикстур.
+//
+// Пример:
+//
+//	go run ./cmd/fakeproviders -addr :9000 -fixtures cmd/fakeproviders/fixtures.example.json
+//	AGIFY_URL=http://localhost:9000/agify GENDERIZE_URL=http://localhost:9000/genderize \
+//	NATIONALIZE_URL=http://localhost:9000/nationalize go run cmd/api/main.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBatchSize — ограничение реальных API на количество name[] в запросе
+const maxBatchSize = 10
+
+// country — элемент распределения nationalize
+type country struct {
+	CountryID   string  `json:"country_id"`
+	Probability float64 `json:"probability"`
+}
+
+// nameFixture — статистика по имени, общая для трёх API
+type nameFixture struct {
+	Age         *int      `json:"age"`
+	Count       int       `json:"count"`
+	Gender      *string   `json:"gender"`
+	Probability float64   `json:"probability"`
+	Country     []country `json:"country"`
+}
+
+// scriptedResponse — заранее заданный ответ провайдера на имя
+type scriptedResponse struct {
+	Provider string          `json:"provider"`
+	Name     string          `json:"name"`
+	Status   int             `json:"status"`
+	Body     json.RawMessage `json:"body"`
+	Times    int             `json:"times"` // сколько раз применить, 0 — всегда
+}
+
+// fixtures — содержимое файла фикстур
+type fixtures struct {
+	Names     map[string]nameFixture `json:"names"`
+	Responses []scriptedResponse     `json:"responses"`
+}
+
+type config struct {
+	addr          string
+	fixturesPath  string
+	apiKey        string
+	dailyLimit    int
+	faultRate     float64
+	faultStatuses []int
+	latency       time.Duration
+	jitter        time.Duration
+	seed          int64
+}
+
+type server struct {
+	cfg config
+
+	mu        sync.Mutex
+	rng       *rand.Rand
+	names     map[string]nameFixture
+	responses []scriptedResponse
+	used      map[string]int // запросов за текущие сутки по провайдерам
+	resetAt   time.Time
+}
+
+func main() {
+	cfg := parseFlags()
+
+	s := newServer(cfg)
+	if cfg.fixturesPath != "" {
+		if err := s.loadFixtures(cfg.fixturesPath); err != nil {
+			log.Fatalf("failed to load fixtures: %v", err)
+		}
+	}
+
+	log.Printf("Fake providers listening on %s (/agify, /genderize, /nationalize)", cfg.addr)
+	if err := http.ListenAndServe(cfg.addr, s.routes()); err != nil {
+		log.Fatalf("server error: %v", err)
+	}
+}
+
+func newServer(cfg config) *server {
+	return &server{
+		cfg:     cfg,
+		rng:     rand.New(rand.NewSource(cfg.seed)),
+		names:   map[string]nameFixture{},
+		used:    map[string]int{},
+		resetAt: nextMidnight(time.Now()),
+	}
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	for _, provider := range []string{"agify", "genderize", "nationalize"} {
+		mux.HandleFunc("/"+provider, s.handle(provider))
+	}
+	return mux
+}
+
+func parseFlags() config {
+	var (
+		cfg      config
+		statuses string
+	)
+	flag.StringVar(&cfg.addr, "addr", ":9000", "адрес для прослушивания")
+	flag.StringVar(&cfg.fixturesPath, "fixtures", "", "JSON-файл с фикстурами имён и заданными ответами")
+	flag.StringVar(&cfg.apiKey, "api-key", "", "если задан, запросы без этого apikey получают 401")
+	flag.IntVar(&cfg.dailyLimit, "daily-limit", 1000, "дневная квота на провайдера (0 — без ограничения)")
+	flag.Float64Var(&cfg.faultRate, "fault-rate", 0, "доля запросов, завершающихся ошибкой (0..1)")
+	flag.StringVar(&statuses, "fault-statuses", "429,500,503", "коды ответов для внедрённых ошибок")
+	flag.DurationVar(&cfg.latency, "latency", 0, "задержка каждого ответа")
+	flag.DurationVar(&cfg.jitter, "jitter", 0, "случайная добавка к задержке (0..jitter)")
+	flag.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "seed генератора для воспроизводимых ошибок и задержек")
+	flag.Parse()
+
+	for _, part := range strings.Split(statuses, ",") {
+		status, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			log.Fatalf("invalid fault status %q", part)
+		}
+		cfg.faultStatuses = append(cfg.faultStatuses, status)
+	}
+	return cfg
+}
+
+func (s *server) loadFixtures(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var f fixtures
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	for name, fixture := range f.Names {
+		s.names[strings.ToLower(name)] = fixture
+	}
+	s.responses = f.Responses
+	return nil
+}
+
+func (s *server) handle(provider string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.sleep()
+
+		query := r.URL.Query()
+		if s.cfg.apiKey != "" && query.Get("apikey") != s.cfg.apiKey {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "Invalid API key"})
+			return
+		}
+
+		names, batch := query["name[]"], true
+		if len(names) == 0 {
+			names, batch = query["name"], false
+		}
+		if len(names) == 0 || names[0] == "" {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": "Missing 'name' parameter"})
+			return
+		}
+		if len(names) > maxBatchSize {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": "Invalid 'name' parameter"})
+			return
+		}
+
+		if !s.consumeQuota(w, provider, len(names)) {
+			writeJSON(w, http.StatusTooManyRequests, map[string]string{"error": "Request limit reached"})
+			return
+		}
+
+		if status, ok := s.injectFault(); ok {
+			writeJSON(w, status, map[string]string{"error": http.StatusText(status)})
+			return
+		}
+
+		// Заданные ответы применяются к каждому имени: успешный подставляется
+		// вместо элемента пакета, ошибка отвечает на весь запрос
+		results := make([]interface{}, len(names))
+		for i, name := range names {
+			scripted, ok := s.scripted(provider, name)
+			if !ok {
+				results[i] = s.response(provider, name)
+				continue
+			}
+			if scripted.Status != http.StatusOK {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(scripted.Status)
+				w.Write(scripted.Body)
+				return
+			}
+			results[i] = scripted.Body
+		}
+		if batch {
+			writeJSON(w, http.StatusOK, results)
+		} else {
+			writeJSON(w, http.StatusOK, results[0])
+		}
+	}
+}
+
+// consumeQuota учитывает запрос в дневной квоте и выставляет заголовки X-Rate-Limit-*
+func (s *server) consumeQuota(w http.ResponseWriter, provider string, n int) bool {
+	if s.cfg.dailyLimit <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.After(s.resetAt) {
+		s.used = map[string]int{}
+		s.resetAt = nextMidnight(now)
+	}
+
+	allowed := s.used[provider]+n <= s.cfg.dailyLimit
+	if allowed {
+		s.used[provider] += n
+	}
+
+	w.Header().Set("X-Rate-Limit-Limit", strconv.Itoa(s.cfg.dailyLimit))
+	w.Header().Set("X-Rate-Limit-Remaining", strconv.Itoa(s.cfg.dailyLimit-s.used[provider]))
+	w.Header().Set("X-Rate-Limit-Reset", strconv.Itoa(int(s.resetAt.Sub(now).Seconds())))
+	return allowed
+}
+
+func (s *server) injectFault() (int, bool) {
+	if s.cfg.faultRate <= 0 || len(s.cfg.faultStatuses) == 0 {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rng.Float64() >= s.cfg.faultRate {
+		return 0, false
+	}
+	return s.cfg.faultStatuses[s.rng.Intn(len(s.cfg.faultStatuses))], true
+}
+
+func (s *server) sleep() {
+	delay := s.cfg.latency
+	if s.cfg.jitter > 0 {
+		s.mu.Lock()
+		delay += time.Duration(s.rng.Int63n(int64(s.cfg.jitter)))
+		s.mu.Unlock()
+	}
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// scripted ищет заданный ответ для провайдера и имени и учитывает его использование
+func (s *server) scripted(provider, name string) (scriptedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.responses {
+		resp := &s.responses[i]
+		if resp.Provider != provider || !strings.EqualFold(resp.Name, name) {
+			continue
+		}
+		if resp.Times < 0 {
+			continue // исчерпан
+		}
+		if resp.Times > 0 {
+			resp.Times--
+			if resp.Times == 0 {
+				resp.Times = -1
+			}
+		}
+		if resp.Status == 0 {
+			resp.Status = http.StatusOK
+		}
+		return *resp, true
+	}
+	return scriptedResponse{}, false
+}
+
+// response формирует ответ в формате реального API
+func (s *server) response(provider, name string) interface{} {
+	fixture, ok := s.names[strings.ToLower(name)]
+	if !ok {
+		fixture = synthetic(name)
+	}
+
+	switch provider {
+	case "agify":
+		return struct {
+			Count int    `json:"count"`
+			Name  string `json:"name"`
+			Age   *int   `json:"age"`
+		}{fixture.Count, name, fixture.Age}
+	case "genderize":
+		return struct {
+			Count       int     `json:"count"`
+			Name        string  `json:"name"`
+			Gender      *string `json:"gender"`
+			Probability float64 `json:"probability"`
+		}{fixture.Count, name, fixture.Gender, fixture.Probability}
+	default:
+		countries := fixture.Country
+		if countries == nil {
+			countries = []country{}
+		}
+		return struct {
+			Count   int       `json:"count"`
+			Name    string    `json:"name"`
+			Country []country `json:"country"`
+		}{fixture.Count, name, countries}
+	}
+}
+
+var syntheticCountries = []string{"RU", "UA", "BY", "KZ", "US", "DE", "PL", "FR", "GB", "IT"}
+
+// synthetic строит стабильные правдоподобные данные по хешу имени
+func synthetic(name string) nameFixture {
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToLower(name)))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	age := 18 + rng.Intn(60)
+	gender := "male"
+	if rng.Intn(2) == 0 {
+		gender = "female"
+	}
+
+	n := 1 + rng.Intn(5)
+	weights := make([]float64, n)
+	var total float64
+	for i := range weights {
+		weights[i] = rng.Float64()
+		total += weights[i]
+	}
+	countries := make([]country, n)
+	offset := rng.Intn(len(syntheticCountries))
+	for i := range countries {
+		countries[i] = country{
+			CountryID:   syntheticCountries[(offset+i)%len(syntheticCountries)],
+			Probability: roundTo(weights[i]/total*0.9, 4),
+		}
+	}
+	sortCountries(countries)
+
+	return nameFixture{
+		Age:         &age,
+		Count:       100 + rng.Intn(100000),
+		Gender:      &gender,
+		Probability: roundTo(0.5+rng.Float64()/2, 2),
+		Country:     countries,
+	}
+}
+
+func sortCountries(countries []country) {
+	sort.Slice(countries, func(i, j int) bool {
+		return countries[i].Probability > countries[j].Probability
+	})
+}
+
+func roundTo(v float64, digits int) float64 {
+	p, _ := strconv.ParseFloat(fmt.Sprintf("%.*f", digits, v), 64)
+	return p
+}
+
+func nextMidnight(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
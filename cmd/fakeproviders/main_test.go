@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T, cfg config, responses ...scriptedResponse) *httptest.Server {
+	t.Helper()
+	s := newServer(cfg)
+	s.responses = responses
+	server := httptest.NewServer(s.routes())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// get выполняет запрос и возвращает статус и разобранное JSON-тело
+func get(t *testing.T, server *httptest.Server, path string, query url.Values) (int, interface{}) {
+	t.Helper()
+	resp, err := http.Get(server.URL + path + "?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var body interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("GET %s: invalid JSON: %v", path, err)
+	}
+	return resp.StatusCode, body
+}
+
+func keys(v interface{}) []string {
+	object, _ := v.(map[string]interface{})
+	result := make([]string, 0, len(object))
+	for key := range object {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestResponseShapes(t *testing.T) {
+	server := newTestServer(t, config{})
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/agify", []string{"age", "count", "name"}},
+		{"/genderize", []string{"count", "gender", "name", "probability"}},
+		{"/nationalize", []string{"count", "country", "name"}},
+	}
+
+	for _, tt := range tests {
+		status, body := get(t, server, tt.path, url.Values{"name": {"Ivan"}})
+		if status != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want 200", tt.path, status)
+		}
+		if got := keys(body); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GET %s fields = %v, want %v", tt.path, got, tt.want)
+		}
+		if name := body.(map[string]interface{})["name"]; name != "Ivan" {
+			t.Errorf("GET %s name = %v, want Ivan", tt.path, name)
+		}
+
+		// Синтетические данные стабильны для одного имени
+		_, again := get(t, server, tt.path, url.Values{"name": {"Ivan"}})
+		if !reflect.DeepEqual(body, again) {
+			t.Errorf("GET %s returned %v, then %v", tt.path, body, again)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	server := newTestServer(t, config{})
+
+	status, body := get(t, server, "/agify", url.Values{"name[]": {"Ivan", "Anna", "Olga"}})
+	items, ok := body.([]interface{})
+	if status != http.StatusOK || !ok || len(items) != 3 {
+		t.Fatalf("batch of 3 = %d %v, want 3 results", status, body)
+	}
+	for i, name := range []string{"Ivan", "Anna", "Olga"} {
+		if got := items[i].(map[string]interface{})["name"]; got != name {
+			t.Errorf("batch item %d name = %v, want %s", i, got, name)
+		}
+	}
+
+	names := make([]string, maxBatchSize+1)
+	for i := range names {
+		names[i] = "Name" + strings.Repeat("x", i)
+	}
+	if status, _ := get(t, server, "/agify", url.Values{"name[]": names[:maxBatchSize]}); status != http.StatusOK {
+		t.Errorf("batch of %d status = %d, want 200", maxBatchSize, status)
+	}
+	if status, _ := get(t, server, "/agify", url.Values{"name[]": names}); status != http.StatusUnprocessableEntity {
+		t.Errorf("batch of %d status = %d, want 422", len(names), status)
+	}
+	if status, _ := get(t, server, "/agify", url.Values{}); status != http.StatusUnprocessableEntity {
+		t.Errorf("missing name status = %d, want 422", status)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	server := newTestServer(t, config{apiKey: "secret"})
+
+	tests := []struct {
+		query url.Values
+		want  int
+	}{
+		{url.Values{"name": {"Ivan"}}, http.StatusUnauthorized},
+		{url.Values{"name": {"Ivan"}, "apikey": {"wrong"}}, http.StatusUnauthorized},
+		{url.Values{"name": {"Ivan"}, "apikey": {"secret"}}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		if status, _ := get(t, server, "/genderize", tt.query); status != tt.want {
+			t.Errorf("GET /genderize?%s status = %d, want %d", tt.query.Encode(), status, tt.want)
+		}
+	}
+}
+
+func TestScriptedTimes(t *testing.T) {
+	server := newTestServer(t, config{}, scriptedResponse{
+		Provider: "genderize", Name: "Flaky", Status: http.StatusServiceUnavailable,
+		Body: json.RawMessage(`{"error":"Service Unavailable"}`), Times: 2,
+	})
+
+	want := []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK, http.StatusOK}
+	for i, status := range want {
+		got, _ := get(t, server, "/genderize", url.Values{"name": {"flaky"}})
+		if got != status {
+			t.Errorf("request %d status = %d, want %d", i+1, got, status)
+		}
+	}
+
+	// Ответ задан только для genderize
+	if status, _ := get(t, server, "/agify", url.Values{"name": {"Flaky"}}); status != http.StatusOK {
+		t.Errorf("agify status = %d, want 200", status)
+	}
+}
+
+func TestScriptedBatch(t *testing.T) {
+	server := newTestServer(t, config{},
+		scriptedResponse{Provider: "agify", Name: "Anna", Body: json.RawMessage(`{"count":5,"name":"Anna","age":99}`), Times: 1},
+		scriptedResponse{Provider: "agify", Name: "Limited", Status: http.StatusTooManyRequests,
+			Body: json.RawMessage(`{"error":"Request limit reached"}`)},
+	)
+
+	status, body := get(t, server, "/agify", url.Values{"name[]": {"Ivan", "Anna"}})
+	items, _ := body.([]interface{})
+	if status != http.StatusOK || len(items) != 2 {
+		t.Fatalf("batch = %d %v, want 2 results", status, body)
+	}
+	if age := items[1].(map[string]interface{})["age"]; age != 99.0 {
+		t.Errorf("scripted batch item age = %v, want 99", age)
+	}
+	if age := items[0].(map[string]interface{})["age"]; age == 99.0 {
+		t.Errorf("unscripted batch item got scripted age")
+	}
+
+	// Ответ для Anna исчерпан
+	_, body = get(t, server, "/agify", url.Values{"name[]": {"Anna"}})
+	if age := body.([]interface{})[0].(map[string]interface{})["age"]; age == 99.0 {
+		t.Errorf("scripted response applied after times ran out")
+	}
+
+	status, body = get(t, server, "/agify", url.Values{"name[]": {"Ivan", "Limited"}})
+	if status != http.StatusTooManyRequests {
+		t.Errorf("batch with scripted error = %d %v, want 429", status, body)
+	}
+}
+
+func TestDailyLimit(t *testing.T) {
+	server := newTestServer(t, config{dailyLimit: 3})
+
+	if status, _ := get(t, server, "/nationalize", url.Values{"name[]": {"A", "B"}}); status != http.StatusOK {
+		t.Fatalf("first batch status = %d, want 200", status)
+	}
+	if status, _ := get(t, server, "/nationalize", url.Values{"name[]": {"C", "D"}}); status != http.StatusTooManyRequests {
+		t.Errorf("batch over limit status = %d, want 429", status)
+	}
+	if status, _ := get(t, server, "/nationalize", url.Values{"name": {"C"}}); status != http.StatusOK {
+		t.Errorf("request within limit status = %d, want 200", status)
+	}
+}
+
+func TestLoadExampleFixtures(t *testing.T) {
+	s := newServer(config{})
+	if err := s.loadFixtures("fixtures.example.json"); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(s.routes())
+	defer server.Close()
+
+	_, body := get(t, server, "/agify", url.Values{"name": {"dmitriy"}})
+	if age := body.(map[string]interface{})["age"]; age != 43.0 {
+		t.Errorf("fixture age = %v, want 43", age)
+	}
+	_, body = get(t, server, "/agify", url.Values{"name": {"Nobody"}})
+	if age := body.(map[string]interface{})["age"]; age != nil {
+		t.Errorf("fixture age = %v, want null", age)
+	}
+}
+
+func TestSortCountries(t *testing.T) {
+	countries := []country{{"RU", 0.1}, {"UA", 0.5}, {"BY", 0.3}}
+	sortCountries(countries)
+	want := []country{{"UA", 0.5}, {"BY", 0.3}, {"RU", 0.1}}
+	if !reflect.DeepEqual(countries, want) {
+		t.Errorf("sortCountries() = %v, want %v", countries, want)
+	}
+}
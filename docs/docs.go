@@ -15,81 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/persons": {
+        "/api/admin/attribute-schema": {
             "get": {
-                "description": "Возвращает список людей с пагинацией и фильтрацией по полю (имя, фамилия, возраст и т.д.)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает JSON Schema, по которой проверяются атрибуты людей арендатора из заголовка X-Tenant-ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Люди"
+                    "Администрирование"
                 ],
-                "summary": "Получить список людей с фильтрацией и пагинацией",
+                "summary": "Схема атрибутов арендатора",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Иван\"",
-                        "description": "Имя",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Иванов\"",
-                        "description": "Фамилия",
-                        "name": "surname",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Минимальный возраст",
-                        "name": "age_min",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Максимальный возраст",
-                        "name": "age_max",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Пол",
-                        "name": "gender",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Национальность",
-                        "name": "nationality",
-                        "in": "query"
+                        "default": "default",
+                        "description": "Арендатор",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список людей",
+                        "description": "Схема атрибутов",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Person"
-                            }
+                            "$ref": "#/definitions/model.AttributeSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Схема не задана",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -100,8 +55,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Добавляет нового человека в систему с обогащёнными данными (возраст, пол, национальность)",
+            "put": {
+                "description": "Создаёт или заменяет JSON Schema атрибутов для арендатора из заголовка X-Tenant-ID. Поддерживается подмножество JSON Schema; уже сохранённые атрибуты не перепроверяются. Только для роли admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -109,29 +64,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Люди"
+                    "Администрирование"
                 ],
-                "summary": "Создать нового человека",
+                "summary": "Задать схему атрибутов арендатора",
                 "parameters": [
                     {
-                        "description": "Данные человека",
-                        "name": "input",
+                        "type": "string",
+                        "default": "default",
+                        "description": "Арендатор",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "JSON Schema",
+                        "name": "schema",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PersonInput"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Человек успешно создан",
+                    "200": {
+                        "description": "Сохранённая схема",
                         "schema": {
-                            "$ref": "#/definitions/model.Person"
+                            "$ref": "#/definitions/model.AttributeSchema"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверная или неподдерживаемая схема",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нужна роль admin",
                         "schema": {
                             "type": "string"
                         }
@@ -143,39 +111,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/persons/{id}": {
-            "get": {
-                "description": "Возвращает данные человека по его уникальному ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "После удаления атрибуты арендатора не проверяются. Только для роли admin.",
                 "tags": [
-                    "Люди"
+                    "Администрирование"
                 ],
-                "summary": "Получить информацию о человеке по ID",
+                "summary": "Удалить схему атрибутов арендатора",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID человека",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "default": "default",
+                        "description": "Арендатор",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Информация о человеке",
+                    "204": {
+                        "description": "Схема удалена"
+                    },
+                    "403": {
+                        "description": "Нужна роль admin",
                         "schema": {
-                            "$ref": "#/definitions/model.Person"
+                            "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Человек не найден",
+                        "description": "Схема не задана",
                         "schema": {
                             "type": "string"
                         }
@@ -187,54 +150,81 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет запись о человеке по уникальному ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/admin/dataset/reload": {
+            "post": {
+                "description": "Перечитывает файл LOCAL_DATASET_PATH без перезапуска сервиса. Только для роли admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Люди"
-                ],
-                "summary": "Удалить человека по ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID человека",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Администрирование"
                 ],
+                "summary": "Перезагрузить локальный набор данных",
                 "responses": {
-                    "204": {
-                        "description": "Человек успешно удалён"
+                    "200": {
+                        "description": "Количество имён в наборе",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
                     },
-                    "400": {
-                        "description": "Неверный формат ID",
+                    "403": {
+                        "description": "Нужна роль admin",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Человек не найден",
+                    "409": {
+                        "description": "Локальный набор данных не подключён",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера",
+                        "description": "Ошибка чтения набора данных",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Обновляет информацию о человеке по его ID",
+            }
+        },
+        "/api/admin/quotas": {
+            "get": {
+                "description": "Возвращает остаток дневных квот agify, genderize и nationalize по данным заголовков X-Rate-Limit-*. Только для роли admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Администрирование"
+                ],
+                "summary": "Состояние квот внешних API",
+                "responses": {
+                    "200": {
+                        "description": "Состояние квот",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.QuotaState"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нужна роль admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/enrich/preview": {
+            "post": {
+                "description": "Нормализует ФИО и прогнозирует возраст, пол и национальность так же, как при создании, но ничего не сохраняет. Возвращает оценки уверенности и время ответа провайдеров; повторные запросы обслуживаются из кэша.",
                 "consumes": [
                     "application/json"
                 ],
@@ -244,28 +234,24 @@ const docTemplate = `{
                 "tags": [
                     "Люди"
                 ],
-                "summary": "Обновить данные человека",
+                "summary": "Предпросмотр обогащения",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID человека",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новые данные",
+                        "description": "Данные человека",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Person"
+                            "$ref": "#/definitions/model.PersonInput"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Данные успешно обновлены"
+                    "200": {
+                        "description": "Результат обогащения",
+                        "schema": {
+                            "$ref": "#/definitions/model.EnrichmentPreview"
+                        }
                     },
                     "400": {
                         "description": "Неверный формат данных",
@@ -273,14 +259,20 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Человек не найден",
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "502": {
+                        "description": "Внешний API отверг ключ доступа",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Квота внешнего API исчерпана",
                         "schema": {
                             "type": "string"
                         }
@@ -288,64 +280,2567 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/api/persons": {
             "get": {
-                "description": "Возвращает статус сервера для проверки его доступности",
+                "description": "Возвращает список людей с пагинацией и фильтрацией по полю (имя, фамилия, возраст и т.д.)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Здоровье"
+                    "Люди"
                 ],
-                "summary": "Проверка доступности API",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
+                "summary": "Получить список людей с фильтрацией и пагинацией",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "model.Person": {
-            "type": "object",
-            "properties": {
-                "age": {
-                    "description": "Возраст\nexample: 30",
-                    "type": "integer"
-                },
-                "gender": {
-                    "description": "Пол (male/female)\nexample: male",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Уникальный идентификатор\nexample: 1",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "Имя\nexample: Иван",
-                    "type": "string"
-                },
-                "nationality": {
-                    "description": "Код страны (2 символа)\nexample: RU",
-                    "type": "string"
-                },
-                "patronymic": {
-                    "description": "Отчество\nexample: Иванович",
-                    "type": "string"
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Иван\"",
+                        "description": "Имя",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Иванов\"",
+                        "description": "Фамилия",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальный возраст",
+                        "name": "age_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальный возраст",
+                        "name": "age_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пол",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Национальность",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Письменность ФИО",
+                        "name": "script",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "example": 0.9,
+                        "description": "Минимальная вероятность пола",
+                        "name": "gender_probability_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная вероятность национальности",
+                        "name": "nationality_probability_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Искать nationality среди всех кандидатов с вероятностью не ниже заданной",
+                        "name": "nationality_candidate_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальный размер выборки agify",
+                        "name": "age_count_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включать мягко удалённые записи (только для роли admin)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-15T00:00:00Z",
+                        "description": "Изменённые не раньше момента (RFC 3339 или YYYY-MM-DD)",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-01,2025-02-01",
+                        "description": "Созданные в интервале from,to (любая граница может быть пустой)",
+                        "name": "created_between",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "vip,needs-review",
+                        "description": "Все перечисленные теги (через запятую)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Хотя бы один из тегов (через запятую)",
+                        "name": "tag_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ни одного из тегов (через запятую)",
+                        "name": "tag_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Встроить связанные данные",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "sales",
+                        "description": "Точное совпадение атрибута верхнего уровня; работает для любого ключа: attr.\u003cключ\u003e=\u003cзначение\u003e",
+                        "name": "attr.department",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список людей",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Person"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат даты",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted доступен только администраторам",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет нового человека в систему с обогащёнными данными (возраст, пол, национальность)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Создать нового человека",
+                "parameters": [
+                    {
+                        "description": "Данные человека",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PersonInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Человек успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Внешний API отверг ключ доступа",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Квота внешнего API исчерпана",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}": {
+            "get": {
+                "description": "Возвращает данные человека по его уникальному ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Получить информацию о человеке по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть и мягко удалённую запись (только для роли admin)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Встроить связанные данные",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация о человеке",
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или expand",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted доступен только администраторам",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Мягко удаляет запись: она пропадает из выборок, но её можно восстановить через POST /api/persons/{id}/restore до окончательного удаления по сроку хранения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Удалить человека по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Человек успешно удалён"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Обновляет информацию о человеке по его ID. Изменённые возраст, пол и национальность получают источник manual в provenance и не перезаписываются автоматическим обогащением.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Обновить данные человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Данные успешно обновлены"
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/addresses": {
+            "get": {
+                "description": "Основной идёт первым",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Почтовые адреса человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Почтовые адреса",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Address"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "С is_primary=true признак основного снимается с остальных. Код страны — ISO 3166-1 alpha-2 заглавными буквами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Добавить почтовый адрес",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddressInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный контакт",
+                        "schema": {
+                            "$ref": "#/definitions/model.Address"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/addresses/{contact_id}": {
+            "put": {
+                "description": "Заменяет контакт целиком. С is_primary=true признак основного снимается с остальных. Код страны — ISO 3166-1 alpha-2 заглавными буквами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Заменить почтовый адрес",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID контакта",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddressInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменённый контакт",
+                        "schema": {
+                            "$ref": "#/definitions/model.Address"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или контакт не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Удалить почтовый адрес",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID контакта",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Контакт удалён"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или контакт не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/as-of": {
+            "get": {
+                "description": "Восстанавливает запись по журналу изменений в том виде, в котором она была в момент at",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Данные человека на момент времени",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-15T10:00:00Z",
+                        "description": "Момент времени (RFC 3339 или YYYY-MM-DD)",
+                        "name": "at",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные на момент at",
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или времени",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "В этот момент записи не существовало",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/emails": {
+            "get": {
+                "description": "Основной идёт первым",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Адреса электронной почты человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Адреса электронной почты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Email"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "С is_primary=true признак основного снимается с остальных.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Добавить адрес электронной почты",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EmailInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный контакт",
+                        "schema": {
+                            "$ref": "#/definitions/model.Email"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Адрес уже добавлен",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/emails/{contact_id}": {
+            "put": {
+                "description": "Заменяет контакт целиком. С is_primary=true признак основного снимается с остальных.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Заменить адрес электронной почты",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID контакта",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EmailInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменённый контакт",
+                        "schema": {
+                            "$ref": "#/definitions/model.Email"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или контакт не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Адрес уже добавлен",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Удалить адрес электронной почты",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID контакта",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Контакт удалён"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или контакт не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/enrich": {
+            "post": {
+                "description": "Заново запрашивает возраст, пол и национальность. С dry_run=true возвращает только список изменений без сохранения. Значения с источником manual перезаписываются только с force=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Повторно обогатить данные человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только показать изменения",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Перезаписать значения, введённые вручную",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменения и данные после обогащения",
+                        "schema": {
+                            "$ref": "#/definitions/model.EnrichmentResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Запись изменялась во время обогащения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Внешний API отверг ключ доступа",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Квота внешнего API исчерпана",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/enrichment-history": {
+            "get": {
+                "description": "Возвращает прежние и новые значения полей для каждого повторного обогащения, новые записи первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "История повторных обогащений",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История обогащений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.EnrichmentHistoryEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/graph": {
+            "get": {
+                "description": "Люди, связанные с выбранным не более чем через depth связей, и связи между ними.\nДля parent и manager ребро направлено от родителя (руководителя) к ребёнку (подчинённому).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Связи"
+                ],
+                "summary": "Граф связей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 2,
+                        "description": "Глубина обхода, 1–5",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Типы связей (parent, spouse, sibling, manager); по умолчанию все",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Граф",
+                        "schema": {
+                            "$ref": "#/definitions/model.RelationGraph"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или параметров",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/history": {
+            "get": {
+                "description": "Возвращает все создания, изменения и удаление записи с данными до и после, автором и идентификатором запроса. Новые записи первыми; журнал доступен и после удаления.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Журнал изменений человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Журнал изменений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PersonHistoryEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Журнал для этого ID пуст",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/phones": {
+            "get": {
+                "description": "Основной идёт первым",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Телефоны человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Телефоны",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Phone"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "С is_primary=true признак основного снимается с остальных. Номер принимается в международном или национальном формате и сохраняется в E.164.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Добавить телефон",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PhoneInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленный контакт",
+                        "schema": {
+                            "$ref": "#/definitions/model.Phone"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Номер уже добавлен",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/phones/{contact_id}": {
+            "put": {
+                "description": "Заменяет контакт целиком. С is_primary=true признак основного снимается с остальных. Номер принимается в международном или национальном формате и сохраняется в E.164.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Заменить телефон",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID контакта",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PhoneInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменённый контакт",
+                        "schema": {
+                            "$ref": "#/definitions/model.Phone"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или контакт не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Номер уже добавлен",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "Контакты"
+                ],
+                "summary": "Удалить телефон",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID контакта",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Контакт удалён"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или контакт не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/relations": {
+            "get": {
+                "description": "role — кем связанный человек приходится выбранному: parent, child, spouse, sibling, manager, report.\nСвязи с удалёнными людьми не показываются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Связи"
+                ],
+                "summary": "Связи человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Связи",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Relation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "role — кем человек related_id приходится выбранному. Связь parent/manager\nне может образовать цикл; у человека не больше двух родителей.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Связи"
+                ],
+                "summary": "Добавить связь",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Связь",
+                        "name": "relation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RelationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Добавленная связь",
+                        "schema": {
+                            "$ref": "#/definitions/model.Relation"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Связь уже есть или противоречит существующим",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/relations/suggestions": {
+            "get": {
+                "description": "Вероятные отцы по отчеству: мужчины с именем, выведенным из отчества, той же фамилией\n(для дочерей — в мужской форме) и правдоподобной разницей в возрасте. Связи не создаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Связи"
+                ],
+                "summary": "Предложения связей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Предложения, начиная с самых вероятных",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.RelationSuggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/relations/{relation_id}": {
+            "delete": {
+                "tags": [
+                    "Связи"
+                ],
+                "summary": "Удалить связь",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID связи",
+                        "name": "relation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Связь удалена"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек или связь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/restore": {
+            "post": {
+                "description": "Отменяет мягкое удаление записи, если она ещё не удалена окончательно",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Люди"
+                ],
+                "summary": "Восстановить удалённого человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Восстановленная запись",
+                        "schema": {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Запись не удалена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/tags": {
+            "post": {
+                "description": "Добавляет теги, которых ещё нет; теги приводятся к нижнему регистру",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Добавить теги человеку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Теги",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Теги человека после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/model.PersonTags"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или тега",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/persons/{id}/tags/{tag}": {
+            "delete": {
+                "description": "Удаление отсутствующего тега не считается ошибкой",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Удалить тег у человека",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID человека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Теги человека после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/model.PersonTags"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Человек не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "description": "Для фасетного поиска: число людей с каждым тегом среди тех, кто проходит те же фильтры, что и GET /api/persons (пагинация не учитывается)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Количество людей по тегам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пол",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Национальность",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Все перечисленные теги (через запятую)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Хотя бы один из тегов (через запятую)",
+                        "name": "tag_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ни одного из тегов (через запятую)",
+                        "name": "tag_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать мягко удалённые записи (только для роли admin)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Теги по убыванию частоты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат фильтра",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted доступен только администраторам",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/bulk": {
+            "post": {
+                "description": "Добавляет и удаляет теги у набора людей (до 1000) в одной транзакции. Отсутствующие и удалённые люди пропускаются и перечисляются в not_found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Массово добавить и удалить теги",
+                "parameters": [
+                    {
+                        "description": "Люди и теги",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Итог изменения",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTagResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или тега",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transliterate": {
+            "get": {
+                "description": "Переводит кириллицу в латиницу по выбранной схеме (simple, iso9, gost779, icao, bgn, uk-kmu2010, be-2007, kk-2021) либо по национальной схеме языка. Для обратимых схем (iso9) поддерживается reverse=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Транслитерация"
+                ],
+                "summary": "Транслитерация текста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Юрий Щукин\"",
+                        "description": "Текст",
+                        "name": "text",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "simple",
+                        "description": "Схема транслитерации",
+                        "name": "scheme",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык для национальной схемы (ru, uk, be, kk) или auto для определения по буквам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обратная транслитерация (латиница → кириллица)",
+                        "name": "reverse",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат транслитерации",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransliterationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неизвестная схема или пустой текст",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Возвращает статус сервера для проверки его доступности",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Здоровье"
+                ],
+                "summary": "Проверка доступности API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.QuotaState": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "reset_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "example: Москва",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "Код страны ISO 3166-1 alpha-2\nexample: RU",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "is_primary": {
+                    "description": "Основной адрес; у человека не больше одного",
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "Назначение: home, work и т.п.\nexample: home",
+                    "type": "string"
+                },
+                "line1": {
+                    "description": "Улица, дом\nexample: ул. Ленина, д. 1",
+                    "type": "string"
+                },
+                "line2": {
+                    "description": "Квартира, офис\nexample: кв. 12",
+                    "type": "string"
+                },
+                "person_id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "postal_code": {
+                    "description": "example: 101000",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "example: Московская область",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AddressInput": {
+            "type": "object",
+            "required": [
+                "city",
+                "country",
+                "line1"
+            ],
+            "properties": {
+                "city": {
+                    "description": "example: Москва",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "country": {
+                    "description": "example: RU",
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "example: home",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "line1": {
+                    "description": "example: ул. Ленина, д. 1",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "line2": {
+                    "description": "example: кв. 12",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "postal_code": {
+                    "description": "example: 101000",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "region": {
+                    "description": "example: Московская область",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "model.AttributeSchema": {
+            "type": "object",
+            "properties": {
+                "schema": {
+                    "description": "Схема (поддерживаемое подмножество JSON Schema)",
+                    "type": "object"
+                },
+                "tenant": {
+                    "description": "Арендатор (заголовок X-Tenant-ID)\nexample: default",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Время последнего изменения схемы\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                },
+                "updated_by": {
+                    "description": "Кто последним изменил схему\nexample: admin@example.com",
+                    "type": "string"
+                }
+            }
+        },
+        "model.BulkTagRequest": {
+            "type": "object",
+            "required": [
+                "person_ids"
+            ],
+            "properties": {
+                "add": {
+                    "description": "Теги для добавления\nexample: [\"imported-2026\"]",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "person_ids": {
+                    "description": "ID людей, не больше 1000\nexample: [1,2,3]",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "remove": {
+                    "description": "Теги для удаления\nexample: [\"needs-review\"]",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.BulkTagResult": {
+            "type": "object",
+            "properties": {
+                "not_found": {
+                    "description": "Люди, которых нет или которые удалены\nexample: [2]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "updated": {
+                    "description": "Люди, у которых теги изменились\nexample: [1,3]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.Contacts": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Address"
+                    }
+                },
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Email"
+                    }
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Phone"
+                    }
+                }
+            }
+        },
+        "model.Email": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "description": "example: ivan.petrov@example.com",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "is_primary": {
+                    "description": "Основной адрес; у человека не больше одного",
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "Назначение: work, personal и т.п.\nexample: work",
+                    "type": "string"
+                },
+                "person_id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EmailInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "example: ivan.petrov@example.com",
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "example: work",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "model.EnrichmentHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Изменения с прежними значениями полей",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldChange"
+                    }
+                },
+                "enriched_at": {
+                    "description": "Время обогащения\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "triggered_by": {
+                    "description": "Кто запустил обогащение: request или scheduler\nexample: scheduler",
+                    "type": "string"
+                }
+            }
+        },
+        "model.EnrichmentPreview": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Результат взят из кэша (timings — от исходного расчёта)",
+                    "type": "boolean"
+                },
+                "person": {
+                    "description": "Нормализованное ФИО с прогнозом и оценками уверенности",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    ]
+                },
+                "timings": {
+                    "description": "Время ответа каждого источника",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProviderTiming"
+                    }
+                }
+            }
+        },
+        "model.EnrichmentResult": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Изменившиеся поля",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldChange"
+                    }
+                },
+                "dry_run": {
+                    "description": "Только расчёт изменений, без сохранения",
+                    "type": "boolean"
+                },
+                "person": {
+                    "description": "Данные человека после обогащения",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Person"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Имя поля\nexample: age",
+                    "type": "string"
+                },
+                "new": {
+                    "description": "Новое значение\nexample: 32"
+                },
+                "old": {
+                    "description": "Прежнее значение\nexample: 30"
+                }
+            }
+        },
+        "model.FieldProvenance": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "Источник: api:\u003cпровайдер\u003e, rule, manual или import\nexample: api:agify",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Время установки значения\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                }
+            }
+        },
+        "model.NationalityCandidate": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "description": "Код страны (2 символа)\nexample: RU",
+                    "type": "string"
+                },
+                "probability": {
+                    "description": "Вероятность (0..1)\nexample: 0.62",
+                    "type": "number"
+                },
+                "rank": {
+                    "description": "Место в распределении, начиная с 1\nexample: 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Person": {
+            "type": "object",
+            "required": [
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "age": {
+                    "description": "Возраст. При известной дате или годе рождения вычисляется на момент запроса,\nиначе — оценка agify или значение, введённое вручную\nexample: 30",
+                    "type": "integer"
+                },
+                "age_count": {
+                    "description": "Размер выборки agify, на которой оценён возраст\nexample: 1250",
+                    "type": "integer"
+                },
+                "attributes": {
+                    "description": "Произвольные атрибуты; проверяются по JSON Schema арендатора.\nВ PATCH отсутствие поля оставляет атрибуты без изменений, {} — очищает.\nexample: {\"employee_id\":\"E-1042\",\"department\":\"sales\"}",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "birth_date": {
+                    "description": "Дата рождения (YYYY-MM-DD)\nexample: 1994-05-17",
+                    "type": "string"
+                },
+                "birth_year": {
+                    "description": "Год рождения, если точная дата неизвестна\nexample: 1994",
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1850
+                },
+                "canonical_name": {
+                    "description": "Полная форма имени, если введена уменьшительная\nexample: Александр",
+                    "type": "string"
+                },
+                "contacts": {
+                    "description": "Контакты; только с expand=contacts",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Contacts"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "description": "Время создания записи\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "Кто создал запись\nexample: operator@example.com",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Время мягкого удаления; видно только с include_deleted=true",
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "description": "Кто удалил запись",
+                    "type": "string"
+                },
+                "enriched_at": {
+                    "description": "Время последнего успешного обогащения\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                },
+                "enrichment_queries": {
+                    "description": "Строки, отправленные каждому провайдеру при обогащении (для отладки)\nexample: {\"agify\":\"Dmitriy\",\"genderize\":\"Dmitriy\",\"nationalize\":\"Dmitriy\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "estimated_age": {
+                    "description": "Оценка возраста agify\nexample: 31",
+                    "type": "integer"
+                },
+                "estimated_age_at": {
+                    "description": "Когда получена оценка возраста\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                },
+                "gender": {
+                    "description": "Пол (male/female)\nexample: male",
+                    "type": "string"
+                },
+                "gender_count": {
+                    "description": "Размер выборки genderize\nexample: 1250",
+                    "type": "integer"
+                },
+                "gender_probability": {
+                    "description": "Вероятность пола по данным genderize (0..1)\nexample: 0.98",
+                    "type": "number"
+                },
+                "gender_source": {
+                    "description": "Источник значения пола: rules (по отчеству/фамилии), api (genderize) или manual\nexample: rules",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор\nexample: 1",
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "Язык ФИО для транслитерации (ru, uk, be, kk), заданный при создании; null — определяется по буквам.\nВ PATCH отсутствие поля оставляет язык без изменений.\nexample: uk",
+                    "type": "string",
+                    "enum": [
+                        "ru",
+                        "uk",
+                        "be",
+                        "kk"
+                    ]
+                },
+                "name": {
+                    "description": "Имя\nexample: Иван",
+                    "type": "string"
+                },
+                "name_original": {
+                    "description": "Имя в том виде, в котором оно было введено\nexample: саша",
+                    "type": "string"
+                },
+                "nationalities": {
+                    "description": "Все страны-кандидаты nationalize по убыванию вероятности",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NationalityCandidate"
+                    }
+                },
+                "nationality": {
+                    "description": "Код страны (2 символа)\nexample: RU",
+                    "type": "string"
+                },
+                "nationality_probability": {
+                    "description": "Вероятность выбранной страны по данным nationalize (0..1)\nexample: 0.62",
+                    "type": "number"
+                },
+                "patronymic": {
+                    "description": "Отчество\nexample: Иванович",
+                    "type": "string"
+                },
+                "patronymic_original": {
+                    "description": "Отчество в том виде, в котором оно было введено\nexample: ивановИЧ",
+                    "type": "string"
+                },
+                "provenance": {
+                    "description": "Происхождение обогащённых полей (age, gender, nationality).\nЗначения с источником manual не перезаписываются автоматическим обогащением.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.FieldProvenance"
+                    }
+                },
+                "script": {
+                    "description": "Письменность ФИО (cyrillic, latin, greek, georgian, armenian, other, mixed)\nexample: cyrillic",
+                    "type": "string"
                 },
                 "surname": {
                     "description": "Фамилия\nexample: Иванов",
                     "type": "string"
+                },
+                "surname_original": {
+                    "description": "Фамилия в том виде, в котором она была введена\nexample: ИВАНОВ",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Теги в нижнем регистре. В PATCH отсутствие поля оставляет теги без изменений.\nexample: [\"vip\",\"needs-review\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "description": "Время последнего изменения записи\nexample: 2025-01-16T12:30:00Z",
+                    "type": "string"
+                },
+                "updated_by": {
+                    "description": "Кто последним изменил запись\nexample: operator@example.com",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PersonHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "Автор изменения\nexample: operator@example.com",
+                    "type": "string"
+                },
+                "changed_at": {
+                    "description": "Время изменения\nexample: 2025-01-15T10:00:00Z",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "new_data": {
+                    "description": "Данные после изменения",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "old_data": {
+                    "description": "Данные до изменения",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "operation": {
+                    "description": "Операция: insert, update, delete, restore, purge или snapshot (состояние на момент включения журнала)\nexample: update",
+                    "type": "string"
+                },
+                "person_id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "request_id": {
+                    "description": "Идентификатор запроса (X-Request-ID)\nexample: 4f8c2a9e0b7d41f6a3c5e1d2b9f07a68",
+                    "type": "string"
                 }
             }
         },
@@ -356,23 +2851,387 @@ const docTemplate = `{
                 "surname"
             ],
             "properties": {
+                "attributes": {
+                    "description": "Произвольные атрибуты; проверяются по JSON Schema арендатора\nexample: {\"employee_id\":\"E-1042\",\"department\":\"sales\"}",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "birth_date": {
+                    "description": "Дата рождения (YYYY-MM-DD); если задана, возраст не запрашивается у agify\nexample: 1994-05-17",
+                    "type": "string"
+                },
+                "birth_year": {
+                    "description": "Год рождения, если точная дата неизвестна\nexample: 1994",
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1850
+                },
+                "language": {
+                    "description": "Язык ФИО для транслитерации (ru, uk, be, kk); по умолчанию определяется по буквам\nexample: uk",
+                    "type": "string",
+                    "enum": [
+                        "ru",
+                        "uk",
+                        "be",
+                        "kk"
+                    ]
+                },
                 "name": {
                     "description": "Имя\nexample: Иван",
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
+                    "type": "string"
                 },
                 "patronymic": {
                     "description": "Отчество\nexample: Иванович",
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
+                    "type": "string"
                 },
                 "surname": {
                     "description": "Фамилия\nexample: Иванов",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Теги (буквы, цифры, «.», «_», «:», «-»; до 64 символов)\nexample: [\"vip\",\"imported-2026\"]",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.PersonSummary": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "example: 58",
+                    "type": "integer"
+                },
+                "gender": {
+                    "description": "example: male",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 2",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "example: Иван",
+                    "type": "string"
+                },
+                "patronymic": {
+                    "description": "example: Сергеевич",
+                    "type": "string"
+                },
+                "surname": {
+                    "description": "example: Петров",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PersonTags": {
+            "type": "object",
+            "properties": {
+                "person_id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "example: [\"needs-review\",\"vip\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.Phone": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "is_primary": {
+                    "description": "Основной номер; у человека не больше одного",
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "Назначение: mobile, work и т.п.\nexample: mobile",
+                    "type": "string"
+                },
+                "person_id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "phone": {
+                    "description": "example: +79161234567",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PhoneInput": {
+            "type": "object",
+            "required": [
+                "phone"
+            ],
+            "properties": {
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "example: mobile",
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
+                    "maxLength": 50
+                },
+                "phone": {
+                    "description": "Номер в международном или национальном формате; сохраняется в E.164\nexample: 8 (916) 123-45-67",
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "model.ProviderTiming": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "description": "Длительность запроса в миллисекундах\nexample: 182.4",
+                    "type": "number"
+                },
+                "error": {
+                    "description": "Текст ошибки, если запрос не удался",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "example: agify",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Источник ответа: api:\u003cпровайдер\u003e или import\nexample: api:agify",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Relation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "example: alice",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "related": {
+                    "description": "Связанный человек",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PersonSummary"
+                        }
+                    ]
+                },
+                "role": {
+                    "description": "Кем связанный человек приходится выбранному: parent, child, spouse, sibling, manager, report\nexample: parent",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Тип связи: parent, spouse, sibling, manager\nexample: parent",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RelationEdge": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "example: 2",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "example: parent",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RelationGraph": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "description": "example: 2",
+                    "type": "integer"
+                },
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RelationEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RelationGraphNode"
+                    }
+                },
+                "root_id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "Граф обрезан по числу людей",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.RelationGraphNode": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "example: 58",
+                    "type": "integer"
+                },
+                "distance": {
+                    "description": "Число связей до исходного человека\nexample: 1",
+                    "type": "integer"
+                },
+                "gender": {
+                    "description": "example: male",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "example: 2",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "example: Иван",
+                    "type": "string"
+                },
+                "patronymic": {
+                    "description": "example: Сергеевич",
+                    "type": "string"
+                },
+                "surname": {
+                    "description": "example: Петров",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RelationInput": {
+            "type": "object",
+            "required": [
+                "related_id",
+                "role"
+            ],
+            "properties": {
+                "related_id": {
+                    "description": "ID связанного человека\nexample: 2",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "role": {
+                    "description": "Кем связанный человек приходится выбранному\nexample: parent",
+                    "type": "string",
+                    "enum": [
+                        "parent",
+                        "child",
+                        "spouse",
+                        "sibling",
+                        "manager",
+                        "report"
+                    ]
+                }
+            }
+        },
+        "model.RelationSuggestion": {
+            "type": "object",
+            "properties": {
+                "person": {
+                    "$ref": "#/definitions/model.PersonSummary"
+                },
+                "reasons": {
+                    "description": "Почему предложена связь\nexample: [\"patronymic Сергеевич matches name Сергей\",\"surname Петров matches\",\"age gap 27 years\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role": {
+                    "description": "Предлагаемая роль человека Person относительно выбранного\nexample: parent",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Уверенность (0..1)\nexample: 0.9",
+                    "type": "number"
+                }
+            }
+        },
+        "model.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "example: 42",
+                    "type": "integer"
+                },
+                "tag": {
+                    "description": "example: vip",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TagsInput": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "description": "example: [\"vip\",\"needs-review\"]",
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.TransliterationResult": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "Язык, если схема выбрана по языку\nexample: uk",
+                    "type": "string"
+                },
+                "result": {
+                    "description": "Результат\nexample: Iurii Shchukin",
+                    "type": "string"
+                },
+                "reverse": {
+                    "description": "Обратная транслитерация (латиница → кириллица)",
+                    "type": "boolean"
+                },
+                "scheme": {
+                    "description": "Схема транслитерации\nexample: icao",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Исходный текст\nexample: Юрий Щукин",
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,93 @@
+// Package auth передаёт аутентифицированного пользователя через контекст запроса.
+// Сам сервис не проверяет учётные данные: пользователя определяет прокси или шлюз
+// перед ним и передаёт в заголовках UserHeader, RolesHeader и TenantHeader.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Заголовки, которые выставляет аутентифицирующий прокси
+const (
+	UserHeader   = "X-Authenticated-User"
+	RolesHeader  = "X-Authenticated-Roles" // роли через запятую
+	TenantHeader = "X-Tenant-ID"
+)
+
+// RoleAdmin — роль администратора
+const RoleAdmin = "admin"
+
+// Anonymous — автор изменений, если пользователь не передан
+const Anonymous = "anonymous"
+
+// DefaultTenant — арендатор, если заголовок TenantHeader не передан
+const DefaultTenant = "default"
+
+// Principal — аутентифицированный пользователь или внутренний процесс
+type Principal struct {
+	Subject string
+	Roles   []string
+	Tenant  string
+}
+
+// HasRole сообщает, есть ли у пользователя роль role
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal возвращает контекст с пользователем p
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает пользователя из контекста
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Actor возвращает имя автора изменений для записи в created_by/updated_by
+func Actor(ctx context.Context) string {
+	if p, ok := FromContext(ctx); ok && p.Subject != "" {
+		return p.Subject
+	}
+	return Anonymous
+}
+
+// Tenant возвращает арендатора, от имени которого выполняется запрос
+func Tenant(ctx context.Context) string {
+	if p, ok := FromContext(ctx); ok && p.Tenant != "" {
+		return p.Tenant
+	}
+	return DefaultTenant
+}
+
+// Middleware кладёт в контекст пользователя из заголовков UserHeader, RolesHeader и TenantHeader
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject := strings.TrimSpace(r.Header.Get(UserHeader))
+		tenant := strings.TrimSpace(r.Header.Get(TenantHeader))
+		if subject == "" && tenant == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var roles []string
+		for _, role := range strings.Split(r.Header.Get(RolesHeader), ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+		ctx := WithPrincipal(r.Context(), Principal{Subject: subject, Roles: roles, Tenant: tenant})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
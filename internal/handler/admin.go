@@ -0,0 +1,161 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/service"
+)
+
+// maxSchemaSize ограничивает размер тела запроса со схемой атрибутов
+const maxSchemaSize = 1 << 20
+
+// GetQuotas обрабатывает GET /api/admin/quotas
+// @Summary Состояние квот внешних API
+// @Description Возвращает остаток дневных квот agify, genderize и nationalize по данным заголовков X-Rate-Limit-*. Только для роли admin.
+// @Tags Администрирование
+// @Produce json
+// @Success 200 {array} api.QuotaState "Состояние квот"
+// @Failure 403 {string} string "Нужна роль admin"
+// @Router /api/admin/quotas [get]
+func (h *PersonHandler) GetQuotas(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetQuotas")
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.service.Quotas())
+	h.logger.Debug("EXIT: GetQuotas")
+}
+
+// ReloadDataset обрабатывает POST /api/admin/dataset/reload
+// @Summary Перезагрузить локальный набор данных
+// @Description Перечитывает файл LOCAL_DATASET_PATH без перезапуска сервиса. Только для роли admin.
+// @Tags Администрирование
+// @Produce json
+// @Success 200 {object} map[string]int "Количество имён в наборе"
+// @Failure 403 {string} string "Нужна роль admin"
+// @Failure 409 {string} string "Локальный набор данных не подключён"
+// @Failure 500 {string} string "Ошибка чтения набора данных"
+// @Router /api/admin/dataset/reload [post]
+func (h *PersonHandler) ReloadDataset(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: ReloadDataset")
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	entries, err := h.service.ReloadDataset()
+	if err != nil {
+		h.logger.Error("Failed to reload dataset", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrNoDataset) {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"entries": entries})
+	h.logger.Debug("EXIT: ReloadDataset")
+}
+
+// GetAttributeSchema обрабатывает GET /api/admin/attribute-schema
+// @Summary Схема атрибутов арендатора
+// @Description Возвращает JSON Schema, по которой проверяются атрибуты людей арендатора из заголовка X-Tenant-ID
+// @Tags Администрирование
+// @Produce json
+// @Param X-Tenant-ID header string false "Арендатор" default(default)
+// @Success 200 {object} model.AttributeSchema "Схема атрибутов"
+// @Failure 404 {string} string "Схема не задана"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/admin/attribute-schema [get]
+func (h *PersonHandler) GetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetAttributeSchema")
+	schema, err := h.service.GetAttributeSchema(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get attribute schema", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schema)
+	h.logger.Debug("EXIT: GetAttributeSchema")
+}
+
+// PutAttributeSchema обрабатывает PUT /api/admin/attribute-schema
+// @Summary Задать схему атрибутов арендатора
+// @Description Создаёт или заменяет JSON Schema атрибутов для арендатора из заголовка X-Tenant-ID. Поддерживается подмножество JSON Schema; уже сохранённые атрибуты не перепроверяются. Только для роли admin.
+// @Tags Администрирование
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string false "Арендатор" default(default)
+// @Param schema body object true "JSON Schema"
+// @Success 200 {object} model.AttributeSchema "Сохранённая схема"
+// @Failure 400 {string} string "Неверная или неподдерживаемая схема"
+// @Failure 403 {string} string "Нужна роль admin"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/admin/attribute-schema [put]
+func (h *PersonHandler) PutAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: PutAttributeSchema")
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(r.Body, maxSchemaSize))
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	schema, err := h.service.SaveAttributeSchema(r.Context(), raw)
+	if err != nil {
+		h.logger.Error("Failed to save attribute schema", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schema)
+	h.logger.Debug("EXIT: PutAttributeSchema")
+}
+
+// DeleteAttributeSchema обрабатывает DELETE /api/admin/attribute-schema
+// @Summary Удалить схему атрибутов арендатора
+// @Description После удаления атрибуты арендатора не проверяются. Только для роли admin.
+// @Tags Администрирование
+// @Param X-Tenant-ID header string false "Арендатор" default(default)
+// @Success 204 "Схема удалена"
+// @Failure 403 {string} string "Нужна роль admin"
+// @Failure 404 {string} string "Схема не задана"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/admin/attribute-schema [delete]
+func (h *PersonHandler) DeleteAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: DeleteAttributeSchema")
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	if err := h.service.DeleteAttributeSchema(r.Context()); err != nil {
+		h.logger.Error("Failed to delete attribute schema", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.Debug("EXIT: DeleteAttributeSchema")
+}
+
+// requireAdmin отвечает 403, если у пользователя нет роли admin
+func requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if principal, _ := auth.FromContext(r.Context()); !principal.HasRole(auth.RoleAdmin) {
+		http.Error(w, "admin role required", http.StatusForbidden)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,426 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/gorilla/mux"
+)
+
+// ListEmails обрабатывает GET /api/persons/{id}/emails
+// @Summary Адреса электронной почты человека
+// @Description Основной идёт первым
+// @Tags Контакты
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.Email "Адреса электронной почты"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/emails [get]
+func (h *PersonHandler) ListEmails(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: ListEmails")
+	personID, _, ok := contactPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	items, err := h.service.ListEmails(r.Context(), personID)
+	if err != nil {
+		h.logger.Error("Failed to list contacts", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+	h.logger.Debug("EXIT: ListEmails")
+}
+
+// CreateEmail обрабатывает POST /api/persons/{id}/emails
+// @Summary Добавить адрес электронной почты
+// @Description С is_primary=true признак основного снимается с остальных.
+// @Tags Контакты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param contact body model.EmailInput true "Данные"
+// @Success 201 {object} model.Email "Добавленный контакт"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 409 {string} string "Адрес уже добавлен"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/emails [post]
+func (h *PersonHandler) CreateEmail(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: CreateEmail")
+	h.saveEmail(w, r, false)
+	h.logger.Debug("EXIT: CreateEmail")
+}
+
+// UpdateEmail обрабатывает PUT /api/persons/{id}/emails/{contact_id}
+// @Summary Заменить адрес электронной почты
+// @Description Заменяет контакт целиком. С is_primary=true признак основного снимается с остальных.
+// @Tags Контакты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param contact_id path int true "ID контакта"
+// @Param contact body model.EmailInput true "Данные"
+// @Success 200 {object} model.Email "Изменённый контакт"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек или контакт не найден"
+// @Failure 409 {string} string "Адрес уже добавлен"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/emails/{contact_id} [put]
+func (h *PersonHandler) UpdateEmail(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: UpdateEmail")
+	h.saveEmail(w, r, true)
+	h.logger.Debug("EXIT: UpdateEmail")
+}
+
+func (h *PersonHandler) saveEmail(w http.ResponseWriter, r *http.Request, update bool) {
+	personID, contactID, ok := contactPath(w, r, update)
+	if !ok {
+		return
+	}
+
+	var input model.EmailInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.service.SaveEmail(r.Context(), personID, contactID, input)
+	if err != nil {
+		h.logger.Error("Failed to save contact", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !update {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(contact)
+}
+
+// DeleteEmail обрабатывает DELETE /api/persons/{id}/emails/{contact_id}
+// @Summary Удалить адрес электронной почты
+// @Tags Контакты
+// @Param id path int true "ID человека"
+// @Param contact_id path int true "ID контакта"
+// @Success 204 "Контакт удалён"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек или контакт не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/emails/{contact_id} [delete]
+func (h *PersonHandler) DeleteEmail(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: DeleteEmail")
+	personID, contactID, ok := contactPath(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEmail(r.Context(), personID, contactID); err != nil {
+		h.logger.Error("Failed to delete contact", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.Debug("EXIT: DeleteEmail")
+}
+
+// ListPhones обрабатывает GET /api/persons/{id}/phones
+// @Summary Телефоны человека
+// @Description Основной идёт первым
+// @Tags Контакты
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.Phone "Телефоны"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/phones [get]
+func (h *PersonHandler) ListPhones(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: ListPhones")
+	personID, _, ok := contactPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	items, err := h.service.ListPhones(r.Context(), personID)
+	if err != nil {
+		h.logger.Error("Failed to list contacts", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+	h.logger.Debug("EXIT: ListPhones")
+}
+
+// CreatePhone обрабатывает POST /api/persons/{id}/phones
+// @Summary Добавить телефон
+// @Description С is_primary=true признак основного снимается с остальных. Номер принимается в международном или национальном формате и сохраняется в E.164.
+// @Tags Контакты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param contact body model.PhoneInput true "Данные"
+// @Success 201 {object} model.Phone "Добавленный контакт"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 409 {string} string "Номер уже добавлен"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/phones [post]
+func (h *PersonHandler) CreatePhone(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: CreatePhone")
+	h.savePhone(w, r, false)
+	h.logger.Debug("EXIT: CreatePhone")
+}
+
+// UpdatePhone обрабатывает PUT /api/persons/{id}/phones/{contact_id}
+// @Summary Заменить телефон
+// @Description Заменяет контакт целиком. С is_primary=true признак основного снимается с остальных. Номер принимается в международном или национальном формате и сохраняется в E.164.
+// @Tags Контакты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param contact_id path int true "ID контакта"
+// @Param contact body model.PhoneInput true "Данные"
+// @Success 200 {object} model.Phone "Изменённый контакт"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек или контакт не найден"
+// @Failure 409 {string} string "Номер уже добавлен"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/phones/{contact_id} [put]
+func (h *PersonHandler) UpdatePhone(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: UpdatePhone")
+	h.savePhone(w, r, true)
+	h.logger.Debug("EXIT: UpdatePhone")
+}
+
+func (h *PersonHandler) savePhone(w http.ResponseWriter, r *http.Request, update bool) {
+	personID, contactID, ok := contactPath(w, r, update)
+	if !ok {
+		return
+	}
+
+	var input model.PhoneInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.service.SavePhone(r.Context(), personID, contactID, input)
+	if err != nil {
+		h.logger.Error("Failed to save contact", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !update {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(contact)
+}
+
+// DeletePhone обрабатывает DELETE /api/persons/{id}/phones/{contact_id}
+// @Summary Удалить телефон
+// @Tags Контакты
+// @Param id path int true "ID человека"
+// @Param contact_id path int true "ID контакта"
+// @Success 204 "Контакт удалён"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек или контакт не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/phones/{contact_id} [delete]
+func (h *PersonHandler) DeletePhone(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: DeletePhone")
+	personID, contactID, ok := contactPath(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePhone(r.Context(), personID, contactID); err != nil {
+		h.logger.Error("Failed to delete contact", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.Debug("EXIT: DeletePhone")
+}
+
+// ListAddresses обрабатывает GET /api/persons/{id}/addresses
+// @Summary Почтовые адреса человека
+// @Description Основной идёт первым
+// @Tags Контакты
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.Address "Почтовые адреса"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/addresses [get]
+func (h *PersonHandler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: ListAddresses")
+	personID, _, ok := contactPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	items, err := h.service.ListAddresses(r.Context(), personID)
+	if err != nil {
+		h.logger.Error("Failed to list contacts", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+	h.logger.Debug("EXIT: ListAddresses")
+}
+
+// CreateAddress обрабатывает POST /api/persons/{id}/addresses
+// @Summary Добавить почтовый адрес
+// @Description С is_primary=true признак основного снимается с остальных. Код страны — ISO 3166-1 alpha-2 заглавными буквами.
+// @Tags Контакты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param contact body model.AddressInput true "Данные"
+// @Success 201 {object} model.Address "Добавленный контакт"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/addresses [post]
+func (h *PersonHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: CreateAddress")
+	h.saveAddress(w, r, false)
+	h.logger.Debug("EXIT: CreateAddress")
+}
+
+// UpdateAddress обрабатывает PUT /api/persons/{id}/addresses/{contact_id}
+// @Summary Заменить почтовый адрес
+// @Description Заменяет контакт целиком. С is_primary=true признак основного снимается с остальных. Код страны — ISO 3166-1 alpha-2 заглавными буквами.
+// @Tags Контакты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param contact_id path int true "ID контакта"
+// @Param contact body model.AddressInput true "Данные"
+// @Success 200 {object} model.Address "Изменённый контакт"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек или контакт не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/addresses/{contact_id} [put]
+func (h *PersonHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: UpdateAddress")
+	h.saveAddress(w, r, true)
+	h.logger.Debug("EXIT: UpdateAddress")
+}
+
+func (h *PersonHandler) saveAddress(w http.ResponseWriter, r *http.Request, update bool) {
+	personID, contactID, ok := contactPath(w, r, update)
+	if !ok {
+		return
+	}
+
+	var input model.AddressInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.service.SaveAddress(r.Context(), personID, contactID, input)
+	if err != nil {
+		h.logger.Error("Failed to save contact", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !update {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(contact)
+}
+
+// DeleteAddress обрабатывает DELETE /api/persons/{id}/addresses/{contact_id}
+// @Summary Удалить почтовый адрес
+// @Tags Контакты
+// @Param id path int true "ID человека"
+// @Param contact_id path int true "ID контакта"
+// @Success 204 "Контакт удалён"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек или контакт не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/addresses/{contact_id} [delete]
+func (h *PersonHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: DeleteAddress")
+	personID, contactID, ok := contactPath(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAddress(r.Context(), personID, contactID); err != nil {
+		h.logger.Error("Failed to delete contact", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.Debug("EXIT: DeleteAddress")
+}
+
+// contactPath читает ID человека и, если withContact, ID контакта из пути.
+// При ошибке отвечает 400 и возвращает ok = false.
+func contactPath(w http.ResponseWriter, r *http.Request, withContact bool) (personID, contactID int64, ok bool) {
+	vars := mux.Vars(r)
+	personID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if withContact {
+		if contactID, err = strconv.ParseInt(vars["contact_id"], 10, 64); err != nil || contactID <= 0 {
+			http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+			return 0, 0, false
+		}
+	}
+	return personID, contactID, true
+}
+
+// expandContacts читает параметр expand; сейчас поддерживается только contacts.
+// При неизвестном значении отвечает 400 и возвращает ok = false.
+func expandContacts(w http.ResponseWriter, r *http.Request) (expand, ok bool) {
+	for _, value := range getListFromQuery(r, "expand") {
+		if value != "contacts" {
+			http.Error(w, "Invalid expand: supported values are contacts", http.StatusBadRequest)
+			return false, false
+		}
+		expand = true
+	}
+	return expand, true
+}
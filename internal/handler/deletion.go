@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/gorilla/mux"
+)
+
+// RestorePerson обрабатывает POST /api/persons/{id}/restore
+// @Summary Восстановить удалённого человека
+// @Description Отменяет мягкое удаление записи, если она ещё не удалена окончательно
+// @Tags Люди
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {object} model.Person "Восстановленная запись"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 409 {string} string "Запись не удалена"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/restore [post]
+func (h *PersonHandler) RestorePerson(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: RestorePerson")
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	person, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to restore person", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(person)
+	h.logger.Debug("EXIT: RestorePerson")
+}
+
+// includeDeleted читает флаг include_deleted; он доступен только роли admin.
+// Если доступа нет, отвечает 403 и возвращает ok = false.
+func (h *PersonHandler) includeDeleted(w http.ResponseWriter, r *http.Request) (include, ok bool) {
+	if !getBoolFromQuery(r, "include_deleted") {
+		return false, true
+	}
+	if principal, _ := auth.FromContext(r.Context()); !principal.HasRole(auth.RoleAdmin) {
+		http.Error(w, "include_deleted requires admin role", http.StatusForbidden)
+		return false, false
+	}
+	return true, true
+}
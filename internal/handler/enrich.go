@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// EnrichPerson обрабатывает POST /api/persons/{id}/enrich
+// @Summary Повторно обогатить данные человека
+// @Description Заново запрашивает возраст, пол и национальность. С dry_run=true возвращает только список изменений без сохранения. Значения с источником manual перезаписываются только с force=true.
+// @Tags Люди
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param dry_run query bool false "Только показать изменения"
+// @Param force query bool false "Перезаписать значения, введённые вручную"
+// @Success 200 {object} model.EnrichmentResult "Изменения и данные после обогащения"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 409 {string} string "Запись изменялась во время обогащения"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 502 {string} string "Внешний API отверг ключ доступа"
+// @Failure 503 {string} string "Квота внешнего API исчерпана"
+// @Router /api/persons/{id}/enrich [post]
+func (h *PersonHandler) EnrichPerson(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: EnrichPerson")
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Reenrich(r.Context(), id, service.ReenrichOptions{
+		DryRun:  getBoolFromQuery(r, "dry_run"),
+		Force:   getBoolFromQuery(r, "force"),
+		Trigger: service.TriggerRequest,
+	})
+	if err != nil {
+		h.logger.Error("Failed to enrich person", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+	h.logger.Debug("EXIT: EnrichPerson")
+}
+
+// GetEnrichmentHistory обрабатывает GET /api/persons/{id}/enrichment-history
+// @Summary История повторных обогащений
+// @Description Возвращает прежние и новые значения полей для каждого повторного обогащения, новые записи первыми
+// @Tags Люди
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.EnrichmentHistoryEntry "История обогащений"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/enrichment-history [get]
+func (h *PersonHandler) GetEnrichmentHistory(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetEnrichmentHistory")
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.service.EnrichmentHistory(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get enrichment history", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+	h.logger.Debug("EXIT: GetEnrichmentHistory")
+}
+
+// PreviewEnrichment обрабатывает POST /api/enrich/preview
+// @Summary Предпросмотр обогащения
+// @Description Нормализует ФИО и прогнозирует возраст, пол и национальность так же, как при создании, но ничего не сохраняет. Возвращает оценки уверенности и время ответа провайдеров; повторные запросы обслуживаются из кэша.
+// @Tags Люди
+// @Accept json
+// @Produce json
+// @Param input body model.PersonInput true "Данные человека"
+// @Success 200 {object} model.EnrichmentPreview "Результат обогащения"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Failure 502 {string} string "Внешний API отверг ключ доступа"
+// @Failure 503 {string} string "Квота внешнего API исчерпана"
+// @Router /api/enrich/preview [post]
+func (h *PersonHandler) PreviewEnrichment(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: PreviewEnrichment")
+	var input model.PersonInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(input); err != nil {
+		h.logger.Error("Input validation error: ", err)
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	preview, err := h.service.Preview(r.Context(), input)
+	if err != nil {
+		h.logger.Error("Failed to preview enrichment", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+	h.logger.Debug("EXIT: PreviewEnrichment")
+}
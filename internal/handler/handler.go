@@ -2,14 +2,20 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
-	"unicode"
+	"strings"
+	"time"
 
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/postgresql"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/service"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/jsonschema"
 	"github.com/evgeniySeleznev/person-enrichment-service/pkg/logger"
-	"github.com/go-playground/validator/v10"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/phone"
 	"github.com/gorilla/mux"
 )
 
@@ -33,22 +39,6 @@ func NewPersonHandler(service *service.PersonService, logger logger.Logger) *Per
 	}
 }
 
-var validate = validator.New()
-
-func isAlphaUnicode(fl validator.FieldLevel) bool {
-	for _, ch := range fl.Field().String() {
-		if !unicode.IsLetter(ch) {
-			return false
-		}
-	}
-	return true
-}
-
-func init() {
-	// Регистрируем кастомную валидацию
-	validate.RegisterValidation("alpha_unicode", isAlphaUnicode)
-}
-
 // CreatePerson обрабатывает POST /api/persons
 // @Summary Создать нового человека
 // @Description Добавляет нового человека в систему с обогащёнными данными (возраст, пол, национальность)
@@ -59,6 +49,8 @@ func init() {
 // @Success 201 {object} model.Person "Человек успешно создан"
 // @Failure 400 {string} string "Неверный формат данных"
 // @Failure 500 {string} string "Ошибка сервера"
+// @Failure 502 {string} string "Внешний API отверг ключ доступа"
+// @Failure 503 {string} string "Квота внешнего API исчерпана"
 // @Router /api/persons [post]
 func (h *PersonHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
 	h.logger.Debug("ENTER: CreatePerson")
@@ -78,7 +70,7 @@ func (h *PersonHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
 	person, err := h.service.Create(r.Context(), input)
 	if err != nil {
 		h.logger.Error("Failed to create person", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusFromError(err))
 		return
 	}
 
@@ -95,7 +87,11 @@ func (h *PersonHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path int true "ID человека"
+// @Param include_deleted query bool false "Вернуть и мягко удалённую запись (только для роли admin)"
+// @Param expand query string false "Встроить связанные данные" enum(contacts)
 // @Success 200 {object} model.Person "Информация о человеке"
+// @Failure 400 {string} string "Неверный формат ID или expand"
+// @Failure 403 {string} string "include_deleted доступен только администраторам"
 // @Failure 404 {string} string "Человек не найден"
 // @Failure 500 {string} string "Ошибка сервера"
 // @Router /api/persons/{id} [get]
@@ -108,12 +104,28 @@ func (h *PersonHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	person, err := h.service.GetByID(r.Context(), id)
+	includeDeleted, ok := h.includeDeleted(w, r)
+	if !ok {
+		return
+	}
+	expand, ok := expandContacts(w, r)
+	if !ok {
+		return
+	}
+
+	person, err := h.service.GetByID(r.Context(), id, includeDeleted)
 	if err != nil {
 		h.logger.Error("Failed to get person", err)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
+	if expand {
+		if err := h.service.ExpandContacts(r.Context(), person); err != nil {
+			h.logger.Error("Failed to load contacts", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(person)
@@ -122,7 +134,7 @@ func (h *PersonHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
 
 // UpdatePerson обрабатывает PATCH /api/persons/{id}
 // @Summary Обновить данные человека
-// @Description Обновляет информацию о человеке по его ID
+// @Description Обновляет информацию о человеке по его ID. Изменённые возраст, пол и национальность получают источник manual в provenance и не перезаписываются автоматическим обогащением.
 // @Tags Люди
 // @Accept json
 // @Produce json
@@ -149,9 +161,15 @@ func (h *PersonHandler) UpdatePerson(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := validate.Struct(person); err != nil {
+		h.logger.Error("Input validation error: ", err)
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	if err := h.service.Update(r.Context(), id, &person); err != nil {
 		h.logger.Error("Failed to update person", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusFromError(err))
 		return
 	}
 
@@ -161,7 +179,7 @@ func (h *PersonHandler) UpdatePerson(w http.ResponseWriter, r *http.Request) {
 
 // DeletePerson обрабатывает DELETE /api/persons/{id}
 // @Summary Удалить человека по ID
-// @Description Удаляет запись о человеке по уникальному ID
+// @Description Мягко удаляет запись: она пропадает из выборок, но её можно восстановить через POST /api/persons/{id}/restore до окончательного удаления по сроку хранения
 // @Tags Люди
 // @Accept json
 // @Produce json
@@ -182,7 +200,7 @@ func (h *PersonHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
 		h.logger.Error("Failed to delete person", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusFromError(err))
 		return
 	}
 
@@ -204,12 +222,64 @@ func (h *PersonHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
 // @Param age_max query int false "Максимальный возраст"
 // @Param gender query string false "Пол" enum(male,female)
 // @Param nationality query string false "Национальность"
+// @Param script query string false "Письменность ФИО" enum(cyrillic,latin,greek,georgian,armenian,other,mixed)
+// @Param gender_probability_min query number false "Минимальная вероятность пола" example(0.9)
+// @Param nationality_probability_min query number false "Минимальная вероятность национальности"
+// @Param nationality_candidate_min query number false "Искать nationality среди всех кандидатов с вероятностью не ниже заданной"
+// @Param age_count_min query int false "Минимальный размер выборки agify"
+// @Param include_deleted query bool false "Включать мягко удалённые записи (только для роли admin)"
+// @Param updated_since query string false "Изменённые не раньше момента (RFC 3339 или YYYY-MM-DD)" example(2025-01-15T00:00:00Z)
+// @Param created_between query string false "Созданные в интервале from,to (любая граница может быть пустой)" example(2025-01-01,2025-02-01)
+// @Param tag query string false "Все перечисленные теги (через запятую)" example(vip,needs-review)
+// @Param tag_any query string false "Хотя бы один из тегов (через запятую)"
+// @Param tag_none query string false "Ни одного из тегов (через запятую)"
+// @Param expand query string false "Встроить связанные данные" enum(contacts)
+// @Param attr.department query string false "Точное совпадение атрибута верхнего уровня; работает для любого ключа: attr.<ключ>=<значение>" example(sales)
 // @Success 200 {array} model.Person "Список людей"
+// @Failure 400 {string} string "Неверный формат даты"
+// @Failure 403 {string} string "include_deleted доступен только администраторам"
 // @Failure 500 {string} string "Ошибка сервера"
 // @Router /api/persons [get]
 func (h *PersonHandler) GetAllPersons(w http.ResponseWriter, r *http.Request) {
 	h.logger.Debug("ENTER: GetAllPersons")
 
+	filterParams, ok := h.parseFilterParams(w, r)
+	if !ok {
+		return
+	}
+	expand, ok := expandContacts(w, r)
+	if !ok {
+		return
+	}
+
+	// Получаем от сервиса с фильтрацией
+	persons, err := h.service.GetAll(r.Context(), filterParams)
+	if err != nil {
+		h.logger.Error("Failed to get persons", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if expand {
+		people := make([]*model.Person, len(persons))
+		for i := range persons {
+			people[i] = &persons[i]
+		}
+		if err := h.service.ExpandContacts(r.Context(), people...); err != nil {
+			h.logger.Error("Failed to load contacts", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Отправляем ответ
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(persons)
+	h.logger.Debug("EXIT: GetAllPersons")
+}
+
+// parseFilterParams читает фильтры и пагинацию списка людей из query-параметров.
+// При ошибке отвечает клиенту и возвращает ok = false.
+func (h *PersonHandler) parseFilterParams(w http.ResponseWriter, r *http.Request) (model.FilterParams, bool) {
 	// Пагинация
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	if page < 1 {
@@ -229,22 +299,70 @@ func (h *PersonHandler) GetAllPersons(w http.ResponseWriter, r *http.Request) {
 		AgeMax:      getIntFromQuery(r, "age_max"),
 		Gender:      getStringFromQuery(r, "gender"),
 		Nationality: getStringFromQuery(r, "nationality"),
+		Script:      getStringFromQuery(r, "script"),
 		Page:        page,
 		PageSize:    pageSize,
+
+		GenderProbabilityMin:      getFloatFromQuery(r, "gender_probability_min"),
+		NationalityProbabilityMin: getFloatFromQuery(r, "nationality_probability_min"),
+		NationalityCandidateMin:   getFloatFromQuery(r, "nationality_candidate_min"),
+		AgeCountMin:               getIntFromQuery(r, "age_count_min"),
+		Attributes:                getAttributeFilters(r),
+		Tags:                      getListFromQuery(r, "tag"),
+		TagsAny:                   getListFromQuery(r, "tag_any"),
+		TagsNone:                  getListFromQuery(r, "tag_none"),
 	}
 
-	// Получаем от сервиса с фильтрацией
-	persons, err := h.service.GetAll(r.Context(), filterParams)
-	if err != nil {
-		h.logger.Error("Failed to get persons", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	var ok bool
+	if filterParams.IncludeDeleted, ok = h.includeDeleted(w, r); !ok {
+		return filterParams, false
 	}
 
-	// Отправляем ответ
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(persons)
-	h.logger.Debug("EXIT: GetAllPersons")
+	var err error
+	if filterParams.UpdatedSince, err = parseTime(r.URL.Query().Get("updated_since")); err != nil {
+		http.Error(w, "Invalid updated_since: "+err.Error(), http.StatusBadRequest)
+		return filterParams, false
+	}
+	if value := r.URL.Query().Get("created_between"); value != "" {
+		from, to, ok := strings.Cut(value, ",")
+		if !ok {
+			http.Error(w, "Invalid created_between: expected from,to", http.StatusBadRequest)
+			return filterParams, false
+		}
+		if filterParams.CreatedFrom, err = parseTime(from); err != nil {
+			http.Error(w, "Invalid created_between: "+err.Error(), http.StatusBadRequest)
+			return filterParams, false
+		}
+		if filterParams.CreatedTo, err = parseTime(to); err != nil {
+			http.Error(w, "Invalid created_between: "+err.Error(), http.StatusBadRequest)
+			return filterParams, false
+		}
+	}
+
+	return filterParams, true
+}
+
+// statusFromError подбирает HTTP-статус для ошибки сервиса
+func statusFromError(err error) int {
+	switch {
+	case errors.Is(err, api.ErrQuotaExhausted):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, api.ErrInvalidAPIKey):
+		return http.StatusBadGateway
+	case errors.Is(err, service.ErrMixedScript), errors.Is(err, service.ErrInvalidBirthDate),
+		errors.Is(err, service.ErrInvalidAttributes), errors.Is(err, jsonschema.ErrInvalidSchema),
+		errors.Is(err, service.ErrInvalidTag), errors.Is(err, phone.ErrInvalidNumber),
+		errors.Is(err, service.ErrInvalidRelation):
+		return http.StatusBadRequest
+	case errors.Is(err, postgresql.ErrPersonNotFound), errors.Is(err, postgresql.ErrAttributeSchemaNotFound),
+		errors.Is(err, postgresql.ErrContactNotFound), errors.Is(err, postgresql.ErrRelationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, postgresql.ErrPersonNotDeleted), errors.Is(err, postgresql.ErrContactExists),
+		errors.Is(err, postgresql.ErrRelationExists), errors.Is(err, postgresql.ErrRelationConflict),
+		errors.Is(err, postgresql.ErrPersonChanged):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
 }
 
 // Утилита для получения строки из query-параметра
@@ -269,6 +387,70 @@ func getIntFromQuery(r *http.Request, key string) *int {
 	return &intValue
 }
 
+// Утилита для получения float64 из query-параметра
+func getFloatFromQuery(r *http.Request, key string) *float64 {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &floatValue
+}
+
+// Утилита для получения флага из query-параметра (true/1); по умолчанию false
+func getBoolFromQuery(r *http.Request, key string) bool {
+	value, err := strconv.ParseBool(r.URL.Query().Get(key))
+	return err == nil && value
+}
+
+// getListFromQuery собирает значения параметра, заданные через запятую и/или повторением; nil, если их нет
+func getListFromQuery(r *http.Request, key string) []string {
+	var result []string
+	for _, value := range r.URL.Query()[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// getAttributeFilters собирает фильтры attr.<ключ>=<значение>; nil, если их нет
+func getAttributeFilters(r *http.Request) map[string]string {
+	var filters map[string]string
+	for param, values := range r.URL.Query() {
+		key, ok := strings.CutPrefix(param, "attr.")
+		if !ok || key == "" || len(values) == 0 {
+			continue
+		}
+		if filters == nil {
+			filters = make(map[string]string)
+		}
+		filters[key] = values[0]
+	}
+	return filters
+}
+
+// parseTime разбирает момент времени в формате RFC 3339 или дату YYYY-MM-DD (UTC);
+// пустая строка — nil
+func parseTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, fmt.Errorf("expected RFC 3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	return &t, nil
+}
+
 // HealthCheck обрабатывает GET /health
 // @Summary Проверка доступности API
 // @Description Возвращает статус сервера для проверки его доступности
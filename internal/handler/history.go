@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetPersonHistory обрабатывает GET /api/persons/{id}/history
+// @Summary Журнал изменений человека
+// @Description Возвращает все создания, изменения и удаление записи с данными до и после, автором и идентификатором запроса. Новые записи первыми; журнал доступен и после удаления.
+// @Tags Люди
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.PersonHistoryEntry "Журнал изменений"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Журнал для этого ID пуст"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/history [get]
+func (h *PersonHandler) GetPersonHistory(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetPersonHistory")
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.service.History(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get person history", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+	h.logger.Debug("EXIT: GetPersonHistory")
+}
+
+// GetPersonAsOf обрабатывает GET /api/persons/{id}/as-of
+// @Summary Данные человека на момент времени
+// @Description Восстанавливает запись по журналу изменений в том виде, в котором она была в момент at
+// @Tags Люди
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param at query string true "Момент времени (RFC 3339 или YYYY-MM-DD)" example(2025-01-15T10:00:00Z)
+// @Success 200 {object} model.Person "Данные на момент at"
+// @Failure 400 {string} string "Неверный формат ID или времени"
+// @Failure 404 {string} string "В этот момент записи не существовало"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/as-of [get]
+func (h *PersonHandler) GetPersonAsOf(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetPersonAsOf")
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	at, err := parseTime(r.URL.Query().Get("at"))
+	if err != nil || at == nil {
+		http.Error(w, "Invalid at: expected RFC 3339 timestamp or YYYY-MM-DD date", http.StatusBadRequest)
+		return
+	}
+
+	person, err := h.service.GetAsOf(r.Context(), id, *at)
+	if err != nil {
+		h.logger.Error("Failed to get person as of", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(person)
+	h.logger.Debug("EXIT: GetPersonAsOf")
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// ListRelations обрабатывает GET /api/persons/{id}/relations
+// @Summary Связи человека
+// @Description role — кем связанный человек приходится выбранному: parent, child, spouse, sibling, manager, report.
+// @Description Связи с удалёнными людьми не показываются.
+// @Tags Связи
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.Relation "Связи"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/relations [get]
+func (h *PersonHandler) ListRelations(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: ListRelations")
+	personID, _, ok := relationPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	relations, err := h.service.ListRelations(r.Context(), personID)
+	if err != nil {
+		h.logger.Error("Failed to list relations", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(relations)
+	h.logger.Debug("EXIT: ListRelations")
+}
+
+// CreateRelation обрабатывает POST /api/persons/{id}/relations
+// @Summary Добавить связь
+// @Description role — кем человек related_id приходится выбранному. Связь parent/manager
+// @Description не может образовать цикл; у человека не больше двух родителей.
+// @Tags Связи
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param relation body model.RelationInput true "Связь"
+// @Success 201 {object} model.Relation "Добавленная связь"
+// @Failure 400 {string} string "Неверный формат данных"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 409 {string} string "Связь уже есть или противоречит существующим"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/relations [post]
+func (h *PersonHandler) CreateRelation(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: CreateRelation")
+	personID, _, ok := relationPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	var input model.RelationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	relation, err := h.service.AddRelation(r.Context(), personID, input)
+	if err != nil {
+		h.logger.Error("Failed to create relation", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(relation)
+	h.logger.Debug("EXIT: CreateRelation")
+}
+
+// DeleteRelation обрабатывает DELETE /api/persons/{id}/relations/{relation_id}
+// @Summary Удалить связь
+// @Tags Связи
+// @Param id path int true "ID человека"
+// @Param relation_id path int true "ID связи"
+// @Success 204 "Связь удалена"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек или связь не найдены"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/relations/{relation_id} [delete]
+func (h *PersonHandler) DeleteRelation(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: DeleteRelation")
+	personID, relationID, ok := relationPath(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRelation(r.Context(), personID, relationID); err != nil {
+		h.logger.Error("Failed to delete relation", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.Debug("EXIT: DeleteRelation")
+}
+
+// GetRelationGraph обрабатывает GET /api/persons/{id}/graph
+// @Summary Граф связей
+// @Description Люди, связанные с выбранным не более чем через depth связей, и связи между ними.
+// @Description Для parent и manager ребро направлено от родителя (руководителя) к ребёнку (подчинённому).
+// @Tags Связи
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param depth query int false "Глубина обхода, 1–5" default(2)
+// @Param type query []string false "Типы связей (parent, spouse, sibling, manager); по умолчанию все" collectionFormat(multi)
+// @Success 200 {object} model.RelationGraph "Граф"
+// @Failure 400 {string} string "Неверный формат ID или параметров"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/graph [get]
+func (h *PersonHandler) GetRelationGraph(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetRelationGraph")
+	personID, _, ok := relationPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	depth := service.DefaultRelationDepth
+	if value := r.URL.Query().Get("depth"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid depth", http.StatusBadRequest)
+			return
+		}
+		depth = parsed
+	}
+
+	graph, err := h.service.RelationGraph(r.Context(), personID, depth, getListFromQuery(r, "type"))
+	if err != nil {
+		h.logger.Error("Failed to build relation graph", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(graph)
+	h.logger.Debug("EXIT: GetRelationGraph")
+}
+
+// GetRelationSuggestions обрабатывает GET /api/persons/{id}/relations/suggestions
+// @Summary Предложения связей
+// @Description Вероятные отцы по отчеству: мужчины с именем, выведенным из отчества, той же фамилией
+// @Description (для дочерей — в мужской форме) и правдоподобной разницей в возрасте. Связи не создаются.
+// @Tags Связи
+// @Produce json
+// @Param id path int true "ID человека"
+// @Success 200 {array} model.RelationSuggestion "Предложения, начиная с самых вероятных"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/relations/suggestions [get]
+func (h *PersonHandler) GetRelationSuggestions(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetRelationSuggestions")
+	personID, _, ok := relationPath(w, r, false)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.service.RelationSuggestions(r.Context(), personID)
+	if err != nil {
+		h.logger.Error("Failed to suggest relations", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
+	h.logger.Debug("EXIT: GetRelationSuggestions")
+}
+
+// relationPath читает ID человека и, с withRelation, ID связи из пути.
+// При ошибке отвечает 400 и возвращает ok = false.
+func relationPath(w http.ResponseWriter, r *http.Request, withRelation bool) (personID, relationID int64, ok bool) {
+	vars := mux.Vars(r)
+	personID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if withRelation {
+		if relationID, err = strconv.ParseInt(vars["relation_id"], 10, 64); err != nil || relationID <= 0 {
+			http.Error(w, "Invalid relation ID", http.StatusBadRequest)
+			return 0, 0, false
+		}
+	}
+	return personID, relationID, true
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/requestid"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/service"
 	"github.com/evgeniySeleznev/person-enrichment-service/pkg/logger"
 	"github.com/gorilla/mux"
@@ -13,6 +15,9 @@ func NewRouter(service *service.PersonService, logger logger.Logger) *mux.Router
 	router := mux.NewRouter()
 	handler := NewPersonHandler(service, logger)
 
+	// Идентификатор запроса для журналов и аудита
+	router.Use(requestid.Middleware)
+
 	// Middleware для логирования запросов
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -21,11 +26,15 @@ func NewRouter(service *service.PersonService, logger logger.Logger) *mux.Router
 				"method", r.Method,
 				"path", r.URL.Path,
 				"remote_addr", r.RemoteAddr,
+				"request_id", requestid.FromContext(r.Context()),
 			)
 			next.ServeHTTP(w, r)
 		})
 	})
 
+	// Пользователь из заголовков аутентифицирующего прокси
+	router.Use(auth.Middleware)
+
 	// Маршруты API
 	api := router.PathPrefix("/api").Subrouter()
 	api.HandleFunc("/persons", handler.CreatePerson).Methods("POST")
@@ -33,6 +42,39 @@ func NewRouter(service *service.PersonService, logger logger.Logger) *mux.Router
 	api.HandleFunc("/persons", handler.GetAllPersons).Methods("GET")
 	api.HandleFunc("/persons/{id}", handler.UpdatePerson).Methods("PATCH")
 	api.HandleFunc("/persons/{id}", handler.DeletePerson).Methods("DELETE")
+	api.HandleFunc("/persons/{id}/restore", handler.RestorePerson).Methods("POST")
+	api.HandleFunc("/persons/{id}/history", handler.GetPersonHistory).Methods("GET")
+	api.HandleFunc("/persons/{id}/as-of", handler.GetPersonAsOf).Methods("GET")
+	api.HandleFunc("/persons/{id}/enrich", handler.EnrichPerson).Methods("POST")
+	api.HandleFunc("/persons/{id}/enrichment-history", handler.GetEnrichmentHistory).Methods("GET")
+	api.HandleFunc("/persons/{id}/tags", handler.AddPersonTags).Methods("POST")
+	api.HandleFunc("/persons/{id}/tags/{tag}", handler.RemovePersonTag).Methods("DELETE")
+	api.HandleFunc("/persons/{id}/emails", handler.ListEmails).Methods("GET")
+	api.HandleFunc("/persons/{id}/emails", handler.CreateEmail).Methods("POST")
+	api.HandleFunc("/persons/{id}/emails/{contact_id}", handler.UpdateEmail).Methods("PUT")
+	api.HandleFunc("/persons/{id}/emails/{contact_id}", handler.DeleteEmail).Methods("DELETE")
+	api.HandleFunc("/persons/{id}/phones", handler.ListPhones).Methods("GET")
+	api.HandleFunc("/persons/{id}/phones", handler.CreatePhone).Methods("POST")
+	api.HandleFunc("/persons/{id}/phones/{contact_id}", handler.UpdatePhone).Methods("PUT")
+	api.HandleFunc("/persons/{id}/phones/{contact_id}", handler.DeletePhone).Methods("DELETE")
+	api.HandleFunc("/persons/{id}/addresses", handler.ListAddresses).Methods("GET")
+	api.HandleFunc("/persons/{id}/addresses", handler.CreateAddress).Methods("POST")
+	api.HandleFunc("/persons/{id}/addresses/{contact_id}", handler.UpdateAddress).Methods("PUT")
+	api.HandleFunc("/persons/{id}/addresses/{contact_id}", handler.DeleteAddress).Methods("DELETE")
+	api.HandleFunc("/persons/{id}/relations", handler.ListRelations).Methods("GET")
+	api.HandleFunc("/persons/{id}/relations", handler.CreateRelation).Methods("POST")
+	api.HandleFunc("/persons/{id}/relations/suggestions", handler.GetRelationSuggestions).Methods("GET")
+	api.HandleFunc("/persons/{id}/relations/{relation_id}", handler.DeleteRelation).Methods("DELETE")
+	api.HandleFunc("/persons/{id}/graph", handler.GetRelationGraph).Methods("GET")
+	api.HandleFunc("/tags", handler.GetTagCounts).Methods("GET")
+	api.HandleFunc("/tags/bulk", handler.BulkTags).Methods("POST")
+	api.HandleFunc("/enrich/preview", handler.PreviewEnrichment).Methods("POST")
+	api.HandleFunc("/admin/quotas", handler.GetQuotas).Methods("GET")
+	api.HandleFunc("/admin/dataset/reload", handler.ReloadDataset).Methods("POST")
+	api.HandleFunc("/admin/attribute-schema", handler.GetAttributeSchema).Methods("GET")
+	api.HandleFunc("/admin/attribute-schema", handler.PutAttributeSchema).Methods("PUT")
+	api.HandleFunc("/admin/attribute-schema", handler.DeleteAttributeSchema).Methods("DELETE")
+	api.HandleFunc("/transliterate", handler.Transliterate).Methods("GET")
 	router.HandleFunc("/health", handler.HealthCheck).Methods("GET")
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
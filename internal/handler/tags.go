@@ -0,0 +1,159 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/gorilla/mux"
+)
+
+// AddPersonTags обрабатывает POST /api/persons/{id}/tags
+// @Summary Добавить теги человеку
+// @Description Добавляет теги, которых ещё нет; теги приводятся к нижнему регистру
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param tags body model.TagsInput true "Теги"
+// @Success 200 {object} model.PersonTags "Теги человека после изменения"
+// @Failure 400 {string} string "Неверный формат ID или тега"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/tags [post]
+func (h *PersonHandler) AddPersonTags(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: AddPersonTags")
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var input model.TagsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.service.AddTags(r.Context(), id, input.Tags)
+	if err != nil {
+		h.logger.Error("Failed to add tags", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+	h.logger.Debug("EXIT: AddPersonTags")
+}
+
+// RemovePersonTag обрабатывает DELETE /api/persons/{id}/tags/{tag}
+// @Summary Удалить тег у человека
+// @Description Удаление отсутствующего тега не считается ошибкой
+// @Tags Теги
+// @Produce json
+// @Param id path int true "ID человека"
+// @Param tag path string true "Тег"
+// @Success 200 {object} model.PersonTags "Теги человека после изменения"
+// @Failure 400 {string} string "Неверный формат ID"
+// @Failure 404 {string} string "Человек не найден"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/persons/{id}/tags/{tag} [delete]
+func (h *PersonHandler) RemovePersonTag(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: RemovePersonTag")
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.service.RemoveTag(r.Context(), id, vars["tag"])
+	if err != nil {
+		h.logger.Error("Failed to remove tag", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+	h.logger.Debug("EXIT: RemovePersonTag")
+}
+
+// BulkTags обрабатывает POST /api/tags/bulk
+// @Summary Массово добавить и удалить теги
+// @Description Добавляет и удаляет теги у набора людей (до 1000) в одной транзакции. Отсутствующие и удалённые люди пропускаются и перечисляются в not_found.
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Param request body model.BulkTagRequest true "Люди и теги"
+// @Success 200 {object} model.BulkTagResult "Итог изменения"
+// @Failure 400 {string} string "Неверный формат данных или тега"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/tags/bulk [post]
+func (h *PersonHandler) BulkTags(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: BulkTags")
+	var request model.BulkTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Error("Invalid JSON", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.BulkTags(r.Context(), request)
+	if err != nil {
+		h.logger.Error("Failed to change tags", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+	h.logger.Debug("EXIT: BulkTags")
+}
+
+// GetTagCounts обрабатывает GET /api/tags
+// @Summary Количество людей по тегам
+// @Description Для фасетного поиска: число людей с каждым тегом среди тех, кто проходит те же фильтры, что и GET /api/persons (пагинация не учитывается)
+// @Tags Теги
+// @Produce json
+// @Param name query string false "Имя"
+// @Param gender query string false "Пол" enum(male,female)
+// @Param nationality query string false "Национальность"
+// @Param tag query string false "Все перечисленные теги (через запятую)"
+// @Param tag_any query string false "Хотя бы один из тегов (через запятую)"
+// @Param tag_none query string false "Ни одного из тегов (через запятую)"
+// @Param include_deleted query bool false "Учитывать мягко удалённые записи (только для роли admin)"
+// @Success 200 {array} model.TagCount "Теги по убыванию частоты"
+// @Failure 400 {string} string "Неверный формат фильтра"
+// @Failure 403 {string} string "include_deleted доступен только администраторам"
+// @Failure 500 {string} string "Ошибка сервера"
+// @Router /api/tags [get]
+func (h *PersonHandler) GetTagCounts(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: GetTagCounts")
+	filterParams, ok := h.parseFilterParams(w, r)
+	if !ok {
+		return
+	}
+
+	counts, err := h.service.TagCounts(r.Context(), filterParams)
+	if err != nil {
+		h.logger.Error("Failed to count tags", err)
+		http.Error(w, err.Error(), statusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(counts)
+	h.logger.Debug("EXIT: GetTagCounts")
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
+)
+
+// Transliterate обрабатывает GET /api/transliterate
+// @Summary Транслитерация текста
+// @Description Переводит кириллицу в латиницу по выбранной схеме (simple, iso9, gost779, icao, bgn, uk-kmu2010, be-2007, kk-2021) либо по национальной схеме языка. Для обратимых схем (iso9) поддерживается reverse=true.
+// @Tags Транслитерация
+// @Produce json
+// @Param text query string true "Текст" example("Юрий Щукин")
+// @Param scheme query string false "Схема транслитерации" default(simple)
+// @Param lang query string false "Язык для национальной схемы (ru, uk, be, kk) или auto для определения по буквам"
+// @Param reverse query bool false "Обратная транслитерация (латиница → кириллица)"
+// @Success 200 {object} model.TransliterationResult "Результат транслитерации"
+// @Failure 400 {string} string "Неизвестная схема или пустой текст"
+// @Router /api/transliterate [get]
+func (h *PersonHandler) Transliterate(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("ENTER: Transliterate")
+	text := r.URL.Query().Get("text")
+	if text == "" {
+		http.Error(w, "Parameter text is required", http.StatusBadRequest)
+		return
+	}
+
+	var (
+		scheme *translit.Scheme
+		err    error
+	)
+	schemeName := r.URL.Query().Get("scheme")
+	lang := r.URL.Query().Get("lang")
+	switch {
+	case schemeName != "":
+		scheme, err = translit.Lookup(schemeName)
+		lang = ""
+	case lang != "":
+		if lang == "auto" {
+			lang = translit.DetectLanguage(text)
+		}
+		scheme, err = translit.ForLanguage(lang)
+	default:
+		scheme, err = translit.Lookup(translit.DefaultScheme)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := model.TransliterationResult{
+		Scheme:   scheme.Name,
+		Text:     text,
+		Language: lang,
+		Reverse:  r.URL.Query().Get("reverse") == "true",
+	}
+	if result.Reverse {
+		result.Result, err = scheme.Reverse(text)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		result.Result = scheme.Transliterate(text)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+	h.logger.Debug("EXIT: Transliterate")
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ограничения длины одного поля ФИО (в символах, после нормализации)
+const (
+	minNameLength = 1
+	maxNameLength = 100
+)
+
+var (
+	errNameLength      = fmt.Errorf("name must be %d to %d characters long", minNameLength, maxNameLength)
+	errNameCharacter   = errors.New("name contains a character that is not a letter or separator")
+	errNameSeparator   = errors.New("name separators must be between letters")
+	errNameCombining   = errors.New("combining mark must follow a letter")
+	errNameMixedScript = errors.New("name mixes scripts within a word")
+)
+
+var validate = validator.New()
+
+func init() {
+	// Регистрируем кастомную валидацию
+	validate.RegisterValidation("person_name", isPersonName)
+}
+
+func isPersonName(fl validator.FieldLevel) bool {
+	return checkPersonName(fl.Field().String()) == nil
+}
+
+// isNameSeparator — допустимые разделители внутри имени: «Анна-Мария», «D'Artagnan», «Мария Луиза».
+// Апостроф бывает и буквой-модификатором: «Марʼяна» (U+02BC, украинский), «ʹ» (U+02B9).
+func isNameSeparator(r rune) bool {
+	switch r {
+	case ' ', '-', '\'', '’', 'ʼ', 'ʹ':
+		return true
+	}
+	return false
+}
+
+// checkPersonName проверяет поле ФИО: буквы (с диакритикой) и разделители
+// между ними, длина в пределах ограничений, без управляющих символов
+// и без смешения письменностей внутри слова. Значение проверяется в форме NFC
+// с нормализованными пробелами — так же, как его сохранит сервис.
+func checkPersonName(value string) error {
+	// Управляющие символы отклоняем до нормализации пробелов, иначе \n и \t
+	// превратились бы в обычный пробел
+	if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		return errNameCharacter
+	}
+	name := norm.NFC.String(strings.Join(strings.Fields(value), " "))
+
+	if n := utf8.RuneCountInString(name); n < minNameLength || n > maxNameLength {
+		return errNameLength
+	}
+
+	afterSeparator := true // начало строки ведёт себя как разделитель
+	afterLetter := false
+	for _, r := range name {
+		switch {
+		case isNameSeparator(r):
+			if afterSeparator {
+				return errNameSeparator
+			}
+			afterSeparator, afterLetter = true, false
+		case unicode.IsLetter(r):
+			afterSeparator, afterLetter = false, true
+		case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me):
+			// Mc — гласные знаки деванагари и других индийских письменностей: «विजय»
+			if !afterLetter {
+				return errNameCombining
+			}
+		default:
+			return errNameCharacter
+		}
+	}
+	if afterSeparator {
+		return errNameSeparator
+	}
+
+	for _, word := range strings.FieldsFunc(name, isNameSeparator) {
+		if translit.DetectScript(word) == translit.ScriptMixed {
+			return errNameMixedScript
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPersonName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		// Русские и славянские имена
+		{"simple cyrillic", "Иван", nil},
+		{"hyphenated", "Анна-Мария", nil},
+		{"two words", "Мария Луиза", nil},
+		{"yo", "Пётр", nil},
+		{"ukrainian apostrophe", "Мар'яна", nil},
+		{"ukrainian modifier apostrophe", "Марʼяна", nil},
+		{"typographic apostrophe cyrillic", "Мар’яна", nil},
+		{"modifier prime", "Podʹjachev", nil},
+		{"ukrainian letters", "Їжакевич", nil},
+		{"belarusian short u", "Ўладзімір", nil},
+		{"kazakh letters", "Нұрсұлтан", nil},
+		{"surrounding spaces", "  Дмитрий ", nil},
+		{"repeated inner spaces", "Мария   Луиза", nil},
+
+		// Латиница
+		{"apostrophe", "D'Artagnan", nil},
+		{"typographic apostrophe", "O’Neil", nil},
+		{"accented", "José", nil},
+		{"decomposed diaeresis", "Zoë", nil},
+		{"vietnamese", "Nguyễn", nil},
+		{"polish", "Łukasz", nil},
+		{"turkish", "Şükrü", nil},
+		{"icelandic", "Ólafur", nil},
+		{"combining tilde", "Ngũgĩ", nil},
+		{"compound surname", "García Márquez", nil},
+
+		// Другие письменности
+		{"greek", "Ξενοφών", nil},
+		{"georgian", "გიორგი", nil},
+		{"armenian", "Արամ", nil},
+		{"chinese", "李", nil},
+		{"arabic", "محمد", nil},
+		{"hebrew", "דוד", nil},
+		{"devanagari", "अर्जुन", nil},
+		{"devanagari spacing vowel sign", "विजय", nil},
+		{"devanagari kiran", "किरण", nil},
+		{"bengali", "অমিত", nil},
+
+		// Длина
+		{"empty", "", errNameLength},
+		{"only spaces", "   ", errNameLength},
+		{"too long", strings.Repeat("а", maxNameLength+1), errNameLength},
+		{"max length", strings.Repeat("а", maxNameLength), nil},
+
+		// Разделители
+		{"leading hyphen", "-Анна", errNameSeparator},
+		{"trailing hyphen", "Анна-", errNameSeparator},
+		{"double hyphen", "Анна--Мария", errNameSeparator},
+		{"hyphen and space", "Анна - Мария", errNameSeparator},
+		{"leading apostrophe", "'Neil", errNameSeparator},
+		{"leading modifier apostrophe", "ʼяна", errNameSeparator},
+		{"double apostrophe", "Марʼ'яна", errNameSeparator},
+
+		// Недопустимые символы
+		{"digit", "Ив4н", errNameCharacter},
+		{"newline", "Иван\nИванов", errNameCharacter},
+		{"tab", "Иван\tИванов", errNameCharacter},
+		{"null byte", "Анна\x00", errNameCharacter},
+		{"zero width joiner", "Ан\u200dна", errNameCharacter},
+		{"sql", "Robert'); DROP TABLE people;--", errNameCharacter},
+		{"dot", "St. John", errNameCharacter},
+		{"emoji", "Анна😀", errNameCharacter},
+		{"leading combining mark", "\u0301Анна", errNameCombining},
+		{"combining mark after separator", "Анна-\u0301Мария", errNameCombining},
+		{"leading spacing mark", "\u093fविजय", errNameCombining},
+		{"enclosing mark after separator", "Анна-\u20ddМария", errNameCombining},
+
+		// Смешение письменностей
+		{"latin a in cyrillic", "Ивaн", errNameMixedScript},
+		{"cyrillic o in latin", "Jоhn", errNameMixedScript},
+		{"different scripts in different words", "Мария Luisa", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPersonName(tt.value)
+			if err != tt.wantErr {
+				t.Errorf("checkPersonName(%q) = %v, want %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPersonNameTag(t *testing.T) {
+	if err := validate.Var("Анна-Мария", "person_name"); err != nil {
+		t.Errorf("valid name rejected: %v", err)
+	}
+	if err := validate.Var("Ив4н", "person_name"); err == nil {
+		t.Error("invalid name accepted")
+	}
+}
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Person представляет данные человека в системе
 // swagger:model
 type Person struct {
@@ -9,27 +14,276 @@ type Person struct {
 
 	// Имя
 	// example: Иван
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required,person_name"`
 
 	// Фамилия
 	// example: Иванов
-	Surname string `json:"surname"`
+	Surname string `json:"surname" validate:"required,person_name"`
 
 	// Отчество
 	// example: Иванович
-	Patronymic *string `json:"patronymic"`
+	Patronymic *string `json:"patronymic" validate:"omitempty,person_name"`
+
+	// Имя в том виде, в котором оно было введено
+	// example: саша
+	NameOriginal *string `json:"name_original"`
+
+	// Фамилия в том виде, в котором она была введена
+	// example: ИВАНОВ
+	SurnameOriginal *string `json:"surname_original"`
+
+	// Отчество в том виде, в котором оно было введено
+	// example: ивановИЧ
+	PatronymicOriginal *string `json:"patronymic_original"`
+
+	// Полная форма имени, если введена уменьшительная
+	// example: Александр
+	CanonicalName *string `json:"canonical_name"`
+
+	// Письменность ФИО (cyrillic, latin, greek, georgian, armenian, other, mixed)
+	// example: cyrillic
+	Script *string `json:"script"`
 
-	// Возраст
+	// Язык ФИО для транслитерации (ru, uk, be, kk), заданный при создании; null — определяется по буквам.
+	// В PATCH отсутствие поля оставляет язык без изменений.
+	// example: uk
+	Language *string `json:"language" validate:"omitempty,oneof=ru uk be kk"`
+
+	// Возраст. При известной дате или годе рождения вычисляется на момент запроса,
+	// иначе — оценка agify или значение, введённое вручную
 	// example: 30
 	Age *int `json:"age"`
 
+	// Размер выборки agify, на которой оценён возраст
+	// example: 1250
+	AgeCount *int `json:"age_count"`
+
+	// Дата рождения (YYYY-MM-DD)
+	// example: 1994-05-17
+	BirthDate *string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+
+	// Год рождения, если точная дата неизвестна
+	// example: 1994
+	BirthYear *int `json:"birth_year" validate:"omitempty,min=1850,max=2100"`
+
+	// Оценка возраста agify
+	// example: 31
+	EstimatedAge *int `json:"estimated_age"`
+
+	// Когда получена оценка возраста
+	// example: 2025-01-15T10:00:00Z
+	EstimatedAgeAt *time.Time `json:"estimated_age_at"`
+
 	// Пол (male/female)
 	// example: male
 	Gender *string `json:"gender"`
 
+	// Вероятность пола по данным genderize (0..1)
+	// example: 0.98
+	GenderProbability *float64 `json:"gender_probability"`
+
+	// Размер выборки genderize
+	// example: 1250
+	GenderCount *int `json:"gender_count"`
+
+	// Источник значения пола: rules (по отчеству/фамилии), api (genderize) или manual
+	// example: rules
+	GenderSource *string `json:"gender_source"`
+
 	// Код страны (2 символа)
 	// example: RU
 	Nationality *string `json:"nationality"`
+
+	// Вероятность выбранной страны по данным nationalize (0..1)
+	// example: 0.62
+	NationalityProbability *float64 `json:"nationality_probability"`
+
+	// Все страны-кандидаты nationalize по убыванию вероятности
+	Nationalities []NationalityCandidate `json:"nationalities"`
+
+	// Строки, отправленные каждому провайдеру при обогащении (для отладки)
+	// example: {"agify":"Dmitriy","genderize":"Dmitriy","nationalize":"Dmitriy"}
+	EnrichmentQueries map[string]string `json:"enrichment_queries,omitempty"`
+
+	// Происхождение обогащённых полей (age, gender, nationality).
+	// Значения с источником manual не перезаписываются автоматическим обогащением.
+	Provenance map[string]FieldProvenance `json:"provenance"`
+
+	// Произвольные атрибуты; проверяются по JSON Schema арендатора.
+	// В PATCH отсутствие поля оставляет атрибуты без изменений, {} — очищает.
+	// example: {"employee_id":"E-1042","department":"sales"}
+	Attributes map[string]interface{} `json:"attributes"`
+
+	// Теги в нижнем регистре. В PATCH отсутствие поля оставляет теги без изменений.
+	// example: ["vip","needs-review"]
+	Tags []string `json:"tags"`
+
+	// Контакты; только с expand=contacts
+	Contacts *Contacts `json:"contacts,omitempty"`
+
+	// Время последнего успешного обогащения
+	// example: 2025-01-15T10:00:00Z
+	EnrichedAt *time.Time `json:"enriched_at"`
+
+	// Время создания записи
+	// example: 2025-01-15T10:00:00Z
+	CreatedAt time.Time `json:"created_at"`
+
+	// Время последнего изменения записи
+	// example: 2025-01-16T12:30:00Z
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Кто создал запись
+	// example: operator@example.com
+	CreatedBy *string `json:"created_by"`
+
+	// Кто последним изменил запись
+	// example: operator@example.com
+	UpdatedBy *string `json:"updated_by"`
+
+	// Время мягкого удаления; видно только с include_deleted=true
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Кто удалил запись
+	DeletedBy *string `json:"deleted_by,omitempty"`
+}
+
+// FieldProvenance — откуда и когда получено значение поля
+// swagger:model
+type FieldProvenance struct {
+	// Источник: api:<провайдер>, rule, manual или import
+	// example: api:agify
+	Source string `json:"source"`
+
+	// Время установки значения
+	// example: 2025-01-15T10:00:00Z
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FieldChange — изменение поля при повторном обогащении
+// swagger:model
+type FieldChange struct {
+	// Имя поля
+	// example: age
+	Field string `json:"field"`
+
+	// Прежнее значение
+	// example: 30
+	Old interface{} `json:"old"`
+
+	// Новое значение
+	// example: 32
+	New interface{} `json:"new"`
+}
+
+// EnrichmentResult — результат повторного обогащения человека
+// swagger:model
+type EnrichmentResult struct {
+	// Только расчёт изменений, без сохранения
+	DryRun bool `json:"dry_run"`
+
+	// Изменившиеся поля
+	Changes []FieldChange `json:"changes"`
+
+	// Данные человека после обогащения
+	Person *Person `json:"person"`
+}
+
+// EnrichmentHistoryEntry — запись истории повторных обогащений
+// swagger:model
+type EnrichmentHistoryEntry struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// Время обогащения
+	// example: 2025-01-15T10:00:00Z
+	EnrichedAt time.Time `json:"enriched_at"`
+
+	// Кто запустил обогащение: request или scheduler
+	// example: scheduler
+	TriggeredBy string `json:"triggered_by"`
+
+	// Изменения с прежними значениями полей
+	Changes []FieldChange `json:"changes"`
+}
+
+// EnrichmentPreview — результат обогащения без сохранения
+// swagger:model
+type EnrichmentPreview struct {
+	// Нормализованное ФИО с прогнозом и оценками уверенности
+	Person *Person `json:"person"`
+
+	// Время ответа каждого источника
+	Timings []ProviderTiming `json:"timings"`
+
+	// Результат взят из кэша (timings — от исходного расчёта)
+	Cached bool `json:"cached"`
+}
+
+// ProviderTiming — время запроса к одному провайдеру обогащения
+// swagger:model
+type ProviderTiming struct {
+	// example: agify
+	Provider string `json:"provider"`
+
+	// Источник ответа: api:<провайдер> или import
+	// example: api:agify
+	Source string `json:"source,omitempty"`
+
+	// Длительность запроса в миллисекундах
+	// example: 182.4
+	DurationMs float64 `json:"duration_ms"`
+
+	// Текст ошибки, если запрос не удался
+	Error string `json:"error,omitempty"`
+}
+
+// PersonHistoryEntry — запись журнала изменений человека
+// swagger:model
+type PersonHistoryEntry struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// example: 1
+	PersonID int64 `json:"person_id"`
+
+	// Операция: insert, update, delete, restore, purge или snapshot (состояние на момент включения журнала)
+	// example: update
+	Operation string `json:"operation"`
+
+	// Данные до изменения
+	OldData map[string]interface{} `json:"old_data"`
+
+	// Данные после изменения
+	NewData map[string]interface{} `json:"new_data"`
+
+	// Автор изменения
+	// example: operator@example.com
+	Actor string `json:"actor"`
+
+	// Идентификатор запроса (X-Request-ID)
+	// example: 4f8c2a9e0b7d41f6a3c5e1d2b9f07a68
+	RequestID *string `json:"request_id"`
+
+	// Время изменения
+	// example: 2025-01-15T10:00:00Z
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// NationalityCandidate — страна-кандидат из распределения nationalize
+// swagger:model
+type NationalityCandidate struct {
+	// Код страны (2 символа)
+	// example: RU
+	CountryID string `json:"country_id"`
+
+	// Вероятность (0..1)
+	// example: 0.62
+	Probability float64 `json:"probability"`
+
+	// Место в распределении, начиная с 1
+	// example: 1
+	Rank int `json:"rank"`
 }
 
 // PersonInput представляет данные для создания человека
@@ -38,15 +292,389 @@ type PersonInput struct {
 
 	// Имя
 	// example: Иван
-	Name string `json:"name" validate:"required,alpha_unicode"`
+	Name string `json:"name" validate:"required,person_name"`
 
 	// Фамилия
 	//example: Иванов
-	Surname string `json:"surname" validate:"required,alpha_unicode"`
+	Surname string `json:"surname" validate:"required,person_name"`
 
 	// Отчество
 	// example: Иванович
-	Patronymic *string `json:"patronymic,omitempty" validate:"omitempty,alpha_unicode"`
+	Patronymic *string `json:"patronymic,omitempty" validate:"omitempty,person_name"`
+
+	// Язык ФИО для транслитерации (ru, uk, be, kk); по умолчанию определяется по буквам
+	// example: uk
+	Language *string `json:"language,omitempty" validate:"omitempty,oneof=ru uk be kk"`
+
+	// Дата рождения (YYYY-MM-DD); если задана, возраст не запрашивается у agify
+	// example: 1994-05-17
+	BirthDate *string `json:"birth_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+
+	// Год рождения, если точная дата неизвестна
+	// example: 1994
+	BirthYear *int `json:"birth_year,omitempty" validate:"omitempty,min=1850,max=2100"`
+
+	// Произвольные атрибуты; проверяются по JSON Schema арендатора
+	// example: {"employee_id":"E-1042","department":"sales"}
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Теги (буквы, цифры, «.», «_», «:», «-»; до 64 символов)
+	// example: ["vip","imported-2026"]
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=50"`
+}
+
+// TagsInput — теги для добавления человеку
+// swagger:model
+type TagsInput struct {
+	// example: ["vip","needs-review"]
+	Tags []string `json:"tags" validate:"required,min=1,max=50"`
+}
+
+// PersonTags — теги человека после изменения
+// swagger:model
+type PersonTags struct {
+	// example: 1
+	PersonID int64 `json:"person_id"`
+
+	// example: ["needs-review","vip"]
+	Tags []string `json:"tags"`
+}
+
+// BulkTagRequest — массовое добавление и удаление тегов
+// swagger:model
+type BulkTagRequest struct {
+	// ID людей, не больше 1000
+	// example: [1,2,3]
+	PersonIDs []int64 `json:"person_ids" validate:"required,min=1,max=1000"`
+
+	// Теги для добавления
+	// example: ["imported-2026"]
+	Add []string `json:"add" validate:"max=50"`
+
+	// Теги для удаления
+	// example: ["needs-review"]
+	Remove []string `json:"remove" validate:"max=50"`
+}
+
+// BulkTagResult — итог массового изменения тегов
+// swagger:model
+type BulkTagResult struct {
+	// Люди, у которых теги изменились
+	// example: [1,3]
+	Updated []int64 `json:"updated"`
+
+	// Люди, которых нет или которые удалены
+	// example: [2]
+	NotFound []int64 `json:"not_found"`
+}
+
+// Contacts — контактные данные человека
+// swagger:model
+type Contacts struct {
+	Emails    []Email   `json:"emails"`
+	Phones    []Phone   `json:"phones"`
+	Addresses []Address `json:"addresses"`
+}
+
+// Email — адрес электронной почты человека
+// swagger:model
+type Email struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// example: 1
+	PersonID int64 `json:"person_id"`
+
+	// example: ivan.petrov@example.com
+	Email string `json:"email"`
+
+	// Назначение: work, personal и т.п.
+	// example: work
+	Label *string `json:"label"`
+
+	// Основной адрес; у человека не больше одного
+	Primary bool `json:"is_primary"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EmailInput — данные для создания или замены адреса электронной почты
+// swagger:model
+type EmailInput struct {
+	// example: ivan.petrov@example.com
+	Email string `json:"email" validate:"required,email,max=254"`
+
+	// example: work
+	Label *string `json:"label,omitempty" validate:"omitempty,max=50"`
+
+	Primary bool `json:"is_primary"`
+}
+
+// Phone — телефон человека в формате E.164
+// swagger:model
+type Phone struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// example: 1
+	PersonID int64 `json:"person_id"`
+
+	// example: +79161234567
+	Phone string `json:"phone"`
+
+	// Назначение: mobile, work и т.п.
+	// example: mobile
+	Label *string `json:"label"`
+
+	// Основной номер; у человека не больше одного
+	Primary bool `json:"is_primary"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PhoneInput — данные для создания или замены телефона
+// swagger:model
+type PhoneInput struct {
+	// Номер в международном или национальном формате; сохраняется в E.164
+	// example: 8 (916) 123-45-67
+	Phone string `json:"phone" validate:"required,max=32"`
+
+	// example: mobile
+	Label *string `json:"label,omitempty" validate:"omitempty,max=50"`
+
+	Primary bool `json:"is_primary"`
+}
+
+// Address — почтовый адрес человека
+// swagger:model
+type Address struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// example: 1
+	PersonID int64 `json:"person_id"`
+
+	// Назначение: home, work и т.п.
+	// example: home
+	Label *string `json:"label"`
+
+	// Улица, дом
+	// example: ул. Ленина, д. 1
+	Line1 string `json:"line1"`
+
+	// Квартира, офис
+	// example: кв. 12
+	Line2 *string `json:"line2"`
+
+	// example: Москва
+	City string `json:"city"`
+
+	// example: Московская область
+	Region *string `json:"region"`
+
+	// example: 101000
+	PostalCode *string `json:"postal_code"`
+
+	// Код страны ISO 3166-1 alpha-2
+	// example: RU
+	Country string `json:"country"`
+
+	// Основной адрес; у человека не больше одного
+	Primary bool `json:"is_primary"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AddressInput — данные для создания или замены почтового адреса
+// swagger:model
+type AddressInput struct {
+	// example: home
+	Label *string `json:"label,omitempty" validate:"omitempty,max=50"`
+
+	// example: ул. Ленина, д. 1
+	Line1 string `json:"line1" validate:"required,max=200"`
+
+	// example: кв. 12
+	Line2 *string `json:"line2,omitempty" validate:"omitempty,max=200"`
+
+	// example: Москва
+	City string `json:"city" validate:"required,max=100"`
+
+	// example: Московская область
+	Region *string `json:"region,omitempty" validate:"omitempty,max=100"`
+
+	// example: 101000
+	PostalCode *string `json:"postal_code,omitempty" validate:"omitempty,max=20"`
+
+	// example: RU
+	Country string `json:"country" validate:"required,iso3166_1_alpha2"`
+
+	Primary bool `json:"is_primary"`
+}
+
+// TagCount — число людей с тегом
+// swagger:model
+type TagCount struct {
+	// example: vip
+	Tag string `json:"tag"`
+
+	// example: 42
+	Count int `json:"count"`
+}
+
+// Типы связей между людьми, как они хранятся
+const (
+	RelationParent  = "parent"
+	RelationSpouse  = "spouse"
+	RelationSibling = "sibling"
+	RelationManager = "manager"
+)
+
+// Роли связанного человека относительно выбранного: к типам связей
+// добавляются обратные роли child (для parent) и report (для manager)
+const (
+	RoleChild  = "child"
+	RoleReport = "report"
+)
+
+// PersonSummary — краткие данные человека в связях и графе
+// swagger:model
+type PersonSummary struct {
+	// example: 2
+	ID int64 `json:"id"`
+
+	// example: Иван
+	Name string `json:"name"`
+
+	// example: Петров
+	Surname string `json:"surname"`
+
+	// example: Сергеевич
+	Patronymic *string `json:"patronymic"`
+
+	// example: male
+	Gender *string `json:"gender"`
+
+	// example: 58
+	Age *int `json:"age"`
+}
+
+// Relation — связь человека с другим человеком
+// swagger:model
+type Relation struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// Тип связи: parent, spouse, sibling, manager
+	// example: parent
+	Type string `json:"type"`
+
+	// Кем связанный человек приходится выбранному: parent, child, spouse, sibling, manager, report
+	// example: parent
+	Role string `json:"role"`
+
+	// Связанный человек
+	Related PersonSummary `json:"related"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// example: alice
+	CreatedBy *string `json:"created_by"`
+}
+
+// RelationInput — связь, добавляемая человеку
+// swagger:model
+type RelationInput struct {
+	// ID связанного человека
+	// example: 2
+	RelatedID int64 `json:"related_id" validate:"required,min=1"`
+
+	// Кем связанный человек приходится выбранному
+	// example: parent
+	Role string `json:"role" validate:"required,oneof=parent child spouse sibling manager report"`
+}
+
+// RelationEdge — связь в графе; для parent и manager направлена от родителя
+// (руководителя) From к ребёнку (подчинённому) To
+// swagger:model
+type RelationEdge struct {
+	// example: 1
+	ID int64 `json:"id"`
+
+	// example: 2
+	From int64 `json:"from"`
+
+	// example: 1
+	To int64 `json:"to"`
+
+	// example: parent
+	Type string `json:"type"`
+}
+
+// RelationGraphNode — человек в графе связей
+// swagger:model
+type RelationGraphNode struct {
+	PersonSummary
+
+	// Число связей до исходного человека
+	// example: 1
+	Distance int `json:"distance"`
+}
+
+// RelationGraph — люди, связанные с исходным не более чем через Depth связей
+// swagger:model
+type RelationGraph struct {
+	// example: 1
+	RootID int64 `json:"root_id"`
+
+	// example: 2
+	Depth int `json:"depth"`
+
+	Nodes []RelationGraphNode `json:"nodes"`
+	Edges []RelationEdge      `json:"edges"`
+
+	// Граф обрезан по числу людей
+	Truncated bool `json:"truncated"`
+}
+
+// RelationSuggestion — возможная связь, найденная по данным людей
+// swagger:model
+type RelationSuggestion struct {
+	// Предлагаемая роль человека Person относительно выбранного
+	// example: parent
+	Role string `json:"role"`
+
+	Person PersonSummary `json:"person"`
+
+	// Уверенность (0..1)
+	// example: 0.9
+	Score float64 `json:"score"`
+
+	// Почему предложена связь
+	// example: ["patronymic Сергеевич matches name Сергей","surname Петров matches","age gap 27 years"]
+	Reasons []string `json:"reasons"`
+}
+
+// AttributeSchema — JSON Schema атрибутов людей для арендатора
+// swagger:model
+type AttributeSchema struct {
+	// Арендатор (заголовок X-Tenant-ID)
+	// example: default
+	Tenant string `json:"tenant"`
+
+	// Схема (поддерживаемое подмножество JSON Schema)
+	Schema json.RawMessage `json:"schema" swaggertype:"object"`
+
+	// Время последнего изменения схемы
+	// example: 2025-01-15T10:00:00Z
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Кто последним изменил схему
+	// example: admin@example.com
+	UpdatedBy *string `json:"updated_by"`
 }
 
 // FilterParams содержит параметры фильтрации
@@ -58,6 +686,46 @@ type FilterParams struct {
 	AgeMax      *int    `json:"age_max"`
 	Gender      *string `json:"gender"`
 	Nationality *string `json:"nationality"`
+	Script      *string `json:"script"`
+
+	// Полные формы имени для поиска по уменьшительной (заполняет сервис)
+	CanonicalNames []string `json:"-"`
+
+	// Минимальная вероятность пола
+	// example: 0.9
+	GenderProbabilityMin *float64 `json:"gender_probability_min"`
+
+	// Минимальная вероятность национальности
+	// example: 0.5
+	NationalityProbabilityMin *float64 `json:"nationality_probability_min"`
+
+	// Если задан, фильтр nationality совпадает с любым кандидатом
+	// распределения с вероятностью не ниже этого значения
+	// example: 0.1
+	NationalityCandidateMin *float64 `json:"nationality_candidate_min"`
+
+	// Минимальный размер выборки agify
+	// example: 100
+	AgeCountMin *int `json:"age_count_min"`
+
+	// Только записи, изменённые не раньше этого момента (для инкрементальной синхронизации)
+	// example: 2025-01-15T00:00:00Z
+	UpdatedSince *time.Time `json:"updated_since"`
+
+	// Границы времени создания (включительно)
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+
+	// Включать мягко удалённые записи (только для администраторов)
+	IncludeDeleted bool `json:"include_deleted"`
+
+	// Точные совпадения атрибутов: ключ верхнего уровня → значение из attr.<ключ>=<значение>
+	Attributes map[string]string `json:"attributes"`
+
+	// Теги: все из Tags, хотя бы один из TagsAny и ни одного из TagsNone
+	Tags     []string `json:"tag"`
+	TagsAny  []string `json:"tag_any"`
+	TagsNone []string `json:"tag_none"`
 
 	// Номер страницы (начиная с 1)
 	// minimum: 1
@@ -70,3 +738,26 @@ type FilterParams struct {
 	// example: 20
 	PageSize int `json:"page_size" validate:"min=1,max=100"`
 }
+
+// TransliterationResult — результат транслитерации текста
+// swagger:model
+type TransliterationResult struct {
+	// Схема транслитерации
+	// example: icao
+	Scheme string `json:"scheme"`
+
+	// Исходный текст
+	// example: Юрий Щукин
+	Text string `json:"text"`
+
+	// Результат
+	// example: Iurii Shchukin
+	Result string `json:"result"`
+
+	// Язык, если схема выбрана по языку
+	// example: uk
+	Language string `json:"language,omitempty"`
+
+	// Обратная транслитерация (латиница → кириллица)
+	Reverse bool `json:"reverse"`
+}
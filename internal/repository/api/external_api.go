@@ -3,133 +3,270 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math/rand"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
 
+// Имена провайдеров обогащения
+const (
+	ProviderAgify       = "agify"
+	ProviderGenderize   = "genderize"
+	ProviderNationalize = "nationalize"
+)
+
+// Письменность, в которой провайдер ожидает имя
+const (
+	ScriptLatin  = "latin"  // транслитерация латиницей (по умолчанию)
+	ScriptNative = "native" // имя как есть, в исходной письменности
+)
+
+// ProviderConfig — настройки одного внешнего API
+type ProviderConfig struct {
+	URL       string
+	Script    string  // ScriptLatin или ScriptNative
+	APIKey    Secret  // ключ платного тарифа, передаётся параметром apikey
+	RateLimit float64 // запросов в секунду, 0 — без ограничения
+	Burst     int
+}
+
+// Config — настройки API-клиента
+type Config struct {
+	Agify        ProviderConfig
+	Genderize    ProviderConfig
+	Nationalize  ProviderConfig
+	QuotaReserve int // сколько запросов квоты держать в запасе
+
+	// NationalityStrategy выбирает страну из распределения nationalize, по умолчанию MostLikely
+	NationalityStrategy NationalityStrategy
+}
+
+// provider — состояние клиента для одного внешнего API
+type provider struct {
+	name    string
+	url     string
+	script  string
+	apiKey  Secret
+	limiter *tokenBucket
+	quota   *quotaTracker
+}
+
+func newProvider(name string, cfg ProviderConfig, quotaReserve int) *provider {
+	script := cfg.Script
+	if script != ScriptNative {
+		script = ScriptLatin
+	}
+	return &provider{
+		name:    name,
+		url:     cfg.URL,
+		script:  script,
+		apiKey:  cfg.APIKey,
+		limiter: newTokenBucket(cfg.RateLimit, cfg.Burst),
+		quota:   newQuotaTracker(name, quotaReserve),
+	}
+}
+
 // APIClient реализует запросы к внешним API
 type APIClient struct {
-	agifyURL       string
-	genderizeURL   string
-	nationalizeURL string
-	httpClient     *http.Client
+	agify       *provider
+	genderize   *provider
+	nationalize *provider
+	httpClient  *http.Client
+
+	nationalityStrategy NationalityStrategy
 }
 
 // NewAPIClient создаёт клиент для работы с API
-func NewAPIClient(agifyURL, genderizeURL, nationalizeURL string) *APIClient {
+func NewAPIClient(cfg Config) *APIClient {
+	strategy := cfg.NationalityStrategy
+	if strategy == nil {
+		strategy = MostLikely{}
+	}
 	return &APIClient{
-		agifyURL:       agifyURL,
-		genderizeURL:   genderizeURL,
-		nationalizeURL: nationalizeURL,
+		agify:       newProvider(ProviderAgify, cfg.Agify, cfg.QuotaReserve),
+		genderize:   newProvider(ProviderGenderize, cfg.Genderize, cfg.QuotaReserve),
+		nationalize: newProvider(ProviderNationalize, cfg.Nationalize, cfg.QuotaReserve),
 		httpClient: &http.Client{
 			Timeout: 5 * time.Second, // Таймаут на запрос
 		},
+		nationalityStrategy: strategy,
 	}
 }
 
-// GetAge возвращает предполагаемый возраст по имени
-func (c *APIClient) GetAge(ctx context.Context, name string) (int, error) {
-	url := fmt.Sprintf("%s?name=%s", c.agifyURL, url.QueryEscape(name))
+// QueryScript сообщает, в какой письменности провайдер ожидает имя
+func (c *APIClient) QueryScript(provider string) string {
+	switch provider {
+	case ProviderAgify:
+		return c.agify.script
+	case ProviderGenderize:
+		return c.genderize.script
+	case ProviderNationalize:
+		return c.nationalize.script
+	}
+	return ScriptLatin
+}
+
+// Quotas возвращает текущее состояние квот всех провайдеров
+func (c *APIClient) Quotas() []QuotaState {
+	return []QuotaState{
+		c.agify.quota.state(),
+		c.genderize.quota.state(),
+		c.nationalize.quota.state(),
+	}
+}
 
-	resp, err := c.doRequest(ctx, url)
+// Source возвращает метку происхождения данных провайдера: api:<провайдер>
+func Source(provider string) string {
+	return "api:" + provider
+}
+
+// AgeResult — ответ agify: возраст и размер выборки
+type AgeResult struct {
+	Age    int
+	Count  int
+	Source string // откуда получено значение, например api:agify
+}
+
+// GenderResult — ответ genderize: пол, вероятность и размер выборки
+type GenderResult struct {
+	Gender      string
+	Probability float64
+	Count       int
+	Source      string
+}
+
+// NationalityResult — выбранная страна nationalize с её вероятностью и полным
+// распределением кандидатов по убыванию вероятности.
+// Пустой CountryID означает, что стратегия не выбрала ни одной страны.
+type NationalityResult struct {
+	CountryID   string
+	Probability float64
+	Candidates  []CountryProbability
+	Source      string
+}
+
+// GetAge возвращает предполагаемый возраст по имени
+func (c *APIClient) GetAge(ctx context.Context, name string) (AgeResult, error) {
+	resp, err := c.doRequest(ctx, c.agify, name)
 	if err != nil {
-		return 0, err
+		return AgeResult{}, err
 	}
 
 	var result struct {
-		Age int `json:"age"`
+		Age   int `json:"age"`
+		Count int `json:"count"`
 	}
 	if err := json.Unmarshal(resp, &result); err != nil {
-		return 0, fmt.Errorf("failed to parse age response: %w", err)
+		return AgeResult{}, fmt.Errorf("failed to parse age response: %w", err)
 	}
 
-	return result.Age, nil
+	return AgeResult{Age: result.Age, Count: result.Count, Source: Source(ProviderAgify)}, nil
 }
 
 // GetGender возвращает предполагаемый пол по имени
-func (c *APIClient) GetGender(ctx context.Context, name string) (string, error) {
-	url := fmt.Sprintf("%s?name=%s", c.genderizeURL, url.QueryEscape(name))
-
-	resp, err := c.doRequest(ctx, url)
+func (c *APIClient) GetGender(ctx context.Context, name string) (GenderResult, error) {
+	resp, err := c.doRequest(ctx, c.genderize, name)
 	if err != nil {
-		return "", err
+		return GenderResult{}, err
 	}
 
 	var result struct {
-		Gender string `json:"gender"`
+		Gender      string  `json:"gender"`
+		Probability float64 `json:"probability"`
+		Count       int     `json:"count"`
 	}
 	if err := json.Unmarshal(resp, &result); err != nil {
-		return "", fmt.Errorf("failed to parse gender response: %w", err)
+		return GenderResult{}, fmt.Errorf("failed to parse gender response: %w", err)
 	}
 
-	return strings.ToLower(result.Gender), nil // "male" вместо "Male"
+	return GenderResult{
+		Gender:      strings.ToLower(result.Gender), // "male" вместо "Male"
+		Probability: result.Probability,
+		Count:       result.Count,
+		Source:      Source(ProviderGenderize),
+	}, nil
 }
 
 // GetNationality возвращает предполагаемую национальность по имени
-func (c *APIClient) GetNationality(ctx context.Context, name string) (string, error) {
-	url := fmt.Sprintf("%s?name=%s", c.nationalizeURL, url.QueryEscape(name))
-
-	resp, err := c.doRequest(ctx, url)
+func (c *APIClient) GetNationality(ctx context.Context, name string) (NationalityResult, error) {
+	resp, err := c.doRequest(ctx, c.nationalize, name)
 	if err != nil {
-		return "", err
+		return NationalityResult{}, err
 	}
 
 	var result struct {
-		Country []struct {
-			CountryID   string  `json:"country_id"`
-			Probability float64 `json:"probability"`
-		} `json:"country"`
+		Country []CountryProbability `json:"country"`
 	}
 	if err := json.Unmarshal(resp, &result); err != nil {
-		return "", fmt.Errorf("failed to parse nationality response: %w", err)
+		return NationalityResult{}, fmt.Errorf("failed to parse nationality response: %w", err)
 	}
 
-	if len(result.Country) == 0 {
-		return "", fmt.Errorf("no nationality data")
+	// Пустой список — не ошибка: стратегия вернёт ok = false, и национальность
+	// будет неизвестной, как и при кандидатах ниже порога
+	candidates := result.Country
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Probability > candidates[j].Probability
+	})
+
+	chosen, ok := c.nationalityStrategy.Select(candidates)
+	if !ok {
+		// Стратегия не уверена в выборе — национальность неизвестна
+		return NationalityResult{Candidates: candidates, Source: Source(ProviderNationalize)}, nil
 	}
+	return NationalityResult{
+		CountryID:   chosen.CountryID,
+		Probability: chosen.Probability,
+		Candidates:  candidates,
+		Source:      Source(ProviderNationalize),
+	}, nil
+}
 
-	// 🎯 Лямбда-функция для взвешенного выбора страны
-	randCountry := func(countries []struct {
-		CountryID   string  `json:"country_id"`
-		Probability float64 `json:"probability"`
-	}) string {
-		var total float64
-		for _, c := range countries {
-			total += c.Probability
-		}
-		r := rand.Float64() * total
-
-		var acc float64
-		for _, c := range countries {
-			acc += c.Probability
-			if r < acc {
-				return c.CountryID
-			}
-		}
-		return countries[len(countries)-1].CountryID // На случай, если что-то пошло не так
+// doRequest общий метод для остальных GET HTTP-запросов API-клиента.
+// Учитывает ограничение частоты и остаток квоты провайдера.
+func (c *APIClient) doRequest(ctx context.Context, p *provider, name string) ([]byte, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("%s rate limiter: %w", p.name, err)
+	}
+	if err := p.quota.acquire(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", p.name, err)
 	}
 
-	return randCountry(result.Country), nil
-}
+	query := url.Values{}
+	query.Set("name", name)
+	if p.apiKey != "" {
+		query.Set("apikey", string(p.apiKey))
+	}
 
-// doRequest общий метод для остальных GET HTTP-запросов API-клиента
-func (c *APIClient) doRequest(ctx context.Context, url string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", p.url+"?"+query.Encode(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		// Текст ошибки может содержать URL вместе с ключом
+		return nil, fmt.Errorf("failed to create %s request", p.name)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
+		// *url.Error содержит полный URL запроса, оставляем только причину
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%s request failed: %w", p.name, err)
 	}
 	defer resp.Body.Close()
 
+	p.quota.update(resp.Header, resp.StatusCode, time.Now())
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%s: %w", p.name, ErrQuotaExhausted)
+	case http.StatusUnauthorized, http.StatusPaymentRequired:
+		return nil, fmt.Errorf("%s returned status %d: %w", p.name, resp.StatusCode, ErrInvalidAPIKey)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
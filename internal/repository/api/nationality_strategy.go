@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Названия стратегий выбора национальности
+const (
+	StrategyMostLikely     = "most_likely"
+	StrategyWeightedRandom = "weighted_random"
+	StrategyThreshold      = "threshold"
+)
+
+// CountryProbability — страна-кандидат из ответа nationalize
+type CountryProbability struct {
+	CountryID   string  `json:"country_id"`
+	Probability float64 `json:"probability"`
+}
+
+// NationalityStrategy выбирает одну страну из распределения nationalize.
+// ok=false означает, что национальность следует считать неизвестной.
+type NationalityStrategy interface {
+	Select(countries []CountryProbability) (country CountryProbability, ok bool)
+}
+
+// MostLikely выбирает страну с наибольшей вероятностью
+type MostLikely struct{}
+
+func (MostLikely) Select(countries []CountryProbability) (CountryProbability, bool) {
+	if len(countries) == 0 {
+		return CountryProbability{}, false
+	}
+	best := countries[0]
+	for _, c := range countries[1:] {
+		if c.Probability > best.Probability {
+			best = c
+		}
+	}
+	return best, true
+}
+
+// WeightedRandom выбирает страну случайно с весами-вероятностями
+type WeightedRandom struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewWeightedRandom создаёт стратегию с заданным генератором (например, с фиксированным seed в тестах)
+func NewWeightedRandom(rng *rand.Rand) *WeightedRandom {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &WeightedRandom{rng: rng}
+}
+
+func (s *WeightedRandom) Select(countries []CountryProbability) (CountryProbability, bool) {
+	if len(countries) == 0 {
+		return CountryProbability{}, false
+	}
+
+	var total float64
+	for _, c := range countries {
+		total += c.Probability
+	}
+
+	// *rand.Rand не потокобезопасен
+	s.mu.Lock()
+	r := s.rng.Float64() * total
+	s.mu.Unlock()
+
+	var acc float64
+	for _, c := range countries {
+		acc += c.Probability
+		if r < acc {
+			return c, true
+		}
+	}
+	return countries[len(countries)-1], true // На случай, если что-то пошло не так
+}
+
+// Threshold выбирает самую вероятную страну, но только если её вероятность
+// не ниже MinProbability; иначе национальность считается неизвестной
+type Threshold struct {
+	MinProbability float64
+}
+
+func (s Threshold) Select(countries []CountryProbability) (CountryProbability, bool) {
+	best, ok := MostLikely{}.Select(countries)
+	if !ok || best.Probability < s.MinProbability {
+		return CountryProbability{}, false
+	}
+	return best, true
+}
+
+// NewNationalityStrategy создаёт стратегию по названию из конфигурации.
+// seed используется weighted_random (0 — случайный), minProbability — threshold.
+func NewNationalityStrategy(name string, seed int64, minProbability float64) (NationalityStrategy, error) {
+	switch name {
+	case "", StrategyMostLikely:
+		return MostLikely{}, nil
+	case StrategyWeightedRandom:
+		if seed == 0 {
+			return NewWeightedRandom(nil), nil
+		}
+		return NewWeightedRandom(rand.New(rand.NewSource(seed))), nil
+	case StrategyThreshold:
+		return Threshold{MinProbability: minProbability}, nil
+	default:
+		return nil, fmt.Errorf("unknown nationality strategy %q", name)
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testCountries = []CountryProbability{
+	{CountryID: "UA", Probability: 0.38},
+	{CountryID: "RU", Probability: 0.31},
+	{CountryID: "BY", Probability: 0.11},
+}
+
+func TestMostLikely(t *testing.T) {
+	tests := []struct {
+		name      string
+		countries []CountryProbability
+		want      string
+		wantOK    bool
+	}{
+		{"empty", nil, "", false},
+		{"single", []CountryProbability{{CountryID: "KZ", Probability: 0.05}}, "KZ", true},
+		{"sorted", testCountries, "UA", true},
+		{"unsorted", []CountryProbability{{"BY", 0.11}, {"RU", 0.31}, {"UA", 0.38}}, "UA", true},
+		{"tie keeps first", []CountryProbability{{"RU", 0.4}, {"UA", 0.4}}, "RU", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MostLikely{}.Select(tt.countries)
+			if got.CountryID != tt.want || ok != tt.wantOK {
+				t.Errorf("Select() = %q, %v; want %q, %v", got.CountryID, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		min       float64
+		countries []CountryProbability
+		want      string
+		wantOK    bool
+	}{
+		{"empty", 0.3, nil, "", false},
+		{"above", 0.3, testCountries, "UA", true},
+		{"equal", 0.38, testCountries, "UA", true},
+		{"below", 0.5, testCountries, "", false},
+		{"zero accepts any", 0, []CountryProbability{{"KZ", 0.01}}, "KZ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Threshold{MinProbability: tt.min}.Select(tt.countries)
+			if got.CountryID != tt.want || ok != tt.wantOK {
+				t.Errorf("Select() = %q, %v; want %q, %v", got.CountryID, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWeightedRandomSeedIsReproducible(t *testing.T) {
+	pick := func(seed int64) []string {
+		strategy, err := NewNationalityStrategy(StrategyWeightedRandom, seed, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := make([]string, 50)
+		for i := range result {
+			c, ok := strategy.Select(testCountries)
+			if !ok {
+				t.Fatal("Select() returned ok = false for non-empty candidates")
+			}
+			result[i] = c.CountryID
+		}
+		return result
+	}
+
+	first, second := pick(42), pick(42)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("selection %d differs for the same seed: %s vs %s", i, first[i], second[i])
+		}
+	}
+}
+
+func TestWeightedRandomFollowsWeights(t *testing.T) {
+	strategy := NewWeightedRandom(rand.New(rand.NewSource(1)))
+	if _, ok := strategy.Select(nil); ok {
+		t.Fatal("Select(nil) returned ok = true")
+	}
+
+	const draws = 20000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		c, _ := strategy.Select(testCountries)
+		counts[c.CountryID]++
+	}
+
+	var total float64
+	for _, c := range testCountries {
+		total += c.Probability
+	}
+	for _, c := range testCountries {
+		want := c.Probability / total
+		got := float64(counts[c.CountryID]) / draws
+		if got < want-0.02 || got > want+0.02 {
+			t.Errorf("%s chosen %.3f of the time, want about %.3f", c.CountryID, got, want)
+		}
+	}
+	if len(counts) != len(testCountries) {
+		t.Errorf("chosen countries %v, want only candidates", counts)
+	}
+}
+
+func TestNewNationalityStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    NationalityStrategy
+		wantErr bool
+	}{
+		{"", MostLikely{}, false},
+		{StrategyMostLikely, MostLikely{}, false},
+		{StrategyThreshold, Threshold{MinProbability: 0.3}, false},
+		{"random", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := NewNationalityStrategy(tt.name, 0, 0.3)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("NewNationalityStrategy(%q) = %v, %v", tt.name, got, err)
+		}
+	}
+}
+
+func TestGetNationalityEmptyCountries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"Zzyzx","country":[]}`))
+	}))
+	defer server.Close()
+
+	for _, strategy := range []NationalityStrategy{MostLikely{}, Threshold{MinProbability: 0.3}} {
+		client := NewAPIClient(Config{
+			Nationalize:         ProviderConfig{URL: server.URL},
+			NationalityStrategy: strategy,
+		})
+		result, err := client.GetNationality(context.Background(), "Zzyzx")
+		if err != nil {
+			t.Fatalf("%T: GetNationality() error = %v, want unknown result", strategy, err)
+		}
+		if result.CountryID != "" || len(result.Candidates) != 0 {
+			t.Errorf("%T: GetNationality() = %+v, want unknown", strategy, result)
+		}
+		if result.Source != Source(ProviderNationalize) {
+			t.Errorf("%T: Source = %q, want %q", strategy, result.Source, Source(ProviderNationalize))
+		}
+	}
+}
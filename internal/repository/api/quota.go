@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted возвращается, когда дневная квота провайдера исчерпана
+var ErrQuotaExhausted = errors.New("provider quota exhausted")
+
+// QuotaState — текущее состояние квоты провайдера по данным заголовков X-Rate-Limit-*
+type QuotaState struct {
+	Provider  string     `json:"provider"`
+	Limit     *int       `json:"limit"`
+	Remaining *int       `json:"remaining"`
+	ResetAt   *time.Time `json:"reset_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// quotaTracker отслеживает остаток квоты провайдера
+type quotaTracker struct {
+	mu        sync.Mutex
+	provider  string
+	reserve   int // сколько запросов держать в запасе
+	known     bool
+	limit     int
+	remaining int
+	resetAt   time.Time
+	updatedAt time.Time
+}
+
+func newQuotaTracker(provider string, reserve int) *quotaTracker {
+	return &quotaTracker{provider: provider, reserve: reserve}
+}
+
+// acquire резервирует один запрос из квоты или отказывает, если квота на исходе
+func (q *quotaTracker) acquire(now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.known {
+		return nil
+	}
+	// Окно квоты сброшено — ждём свежих заголовков
+	if !q.resetAt.IsZero() && now.After(q.resetAt) {
+		q.known = false
+		return nil
+	}
+	if q.remaining <= q.reserve {
+		return ErrQuotaExhausted
+	}
+	q.remaining--
+	return nil
+}
+
+// update обновляет состояние квоты по заголовкам ответа
+func (q *quotaTracker) update(header http.Header, statusCode int, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	remaining, hasRemaining := headerInt(header, "X-Rate-Limit-Remaining")
+	if limit, ok := headerInt(header, "X-Rate-Limit-Limit"); ok {
+		q.limit = limit
+	}
+	if reset, ok := headerInt(header, "X-Rate-Limit-Reset"); ok {
+		q.resetAt = now.Add(time.Duration(reset) * time.Second)
+	} else if retry, ok := headerInt(header, "Retry-After"); ok {
+		q.resetAt = now.Add(time.Duration(retry) * time.Second)
+	}
+
+	switch {
+	case hasRemaining:
+		q.remaining = remaining
+	case statusCode == http.StatusTooManyRequests:
+		q.remaining = 0
+	default:
+		return
+	}
+	q.known = true
+	q.updatedAt = now
+}
+
+// state возвращает снимок состояния квоты
+func (q *quotaTracker) state() QuotaState {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	state := QuotaState{Provider: q.provider}
+	if !q.known {
+		return state
+	}
+	limit, remaining := q.limit, q.remaining
+	updatedAt := q.updatedAt
+	if limit > 0 {
+		state.Limit = &limit
+	}
+	state.Remaining = &remaining
+	state.UpdatedAt = &updatedAt
+	if !q.resetAt.IsZero() {
+		resetAt := q.resetAt
+		state.ResetAt = &resetAt
+	}
+	return state
+}
+
+func headerInt(header http.Header, key string) (int, bool) {
+	value := header.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
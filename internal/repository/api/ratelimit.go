@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket — клиентский ограничитель частоты запросов к одному провайдеру
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // токенов в секунду
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket создаёт ограничитель; при rate <= 0 ограничение отключено (nil)
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait ставит запрос в очередь до появления свободного токена или отмены контекста
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import "errors"
+
+// ErrInvalidAPIKey возвращается, когда провайдер отверг ключ (401/402)
+var ErrInvalidAPIKey = errors.New("invalid or expired API key")
+
+// Secret — строка с ключом доступа, которая не попадает в логи и JSON
+type Secret string
+
+// String скрывает значение при форматировании через %s и %v
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// GoString скрывает значение при форматировании через %#v
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// MarshalJSON скрывает значение при сериализации
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
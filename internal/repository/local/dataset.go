@@ -0,0 +1,263 @@
+// Package local реализует обогащение по локальному набору данных
+// (имя → возраст, пол, распределение стран) без обращения к сети
+package local
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+)
+
+// Source — метка происхождения значений из локального набора данных
+const Source = "import"
+
+// ErrNameNotFound возвращается, если имени нет в наборе данных
+var ErrNameNotFound = errors.New("name not found in local dataset")
+
+// defaultDataset — небольшой набор данных, поставляемый с сервисом
+//
+//go:embed default_dataset.json
+var defaultDataset []byte
+
+// entry — статистика по одному имени. Aliases — другие написания того же имени,
+// например транслитерации по разным схемам («tatyana», «tatana» для «tatiana»).
+type entry struct {
+	Name              string                   `json:"name"`
+	Aliases           []string                 `json:"aliases,omitempty"`
+	Age               int                      `json:"age"`
+	Count             int                      `json:"count"`
+	Gender            string                   `json:"gender"`
+	GenderProbability float64                  `json:"gender_probability"`
+	GenderCount       int                      `json:"gender_count"`
+	Countries         []api.CountryProbability `json:"countries"`
+}
+
+// Dataset — источник обогащения по локальному файлу (JSON или CSV).
+// Безопасен для конкурентного использования и перезагрузки.
+type Dataset struct {
+	mu       sync.RWMutex
+	path     string
+	entries  map[string]entry
+	strategy api.NationalityStrategy
+}
+
+// NewDataset загружает набор данных из path; при пустом path используется встроенный.
+// strategy выбирает страну из распределения, по умолчанию api.MostLikely.
+func NewDataset(path string, strategy api.NationalityStrategy) (*Dataset, error) {
+	if strategy == nil {
+		strategy = api.MostLikely{}
+	}
+	d := &Dataset{path: path, strategy: strategy}
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Reload перечитывает файл набора данных. При ошибке остаётся прежний набор.
+func (d *Dataset) Reload() error {
+	var (
+		entries []entry
+		err     error
+	)
+	if d.path == "" {
+		entries, err = parseJSON(bytes.NewReader(defaultDataset))
+	} else {
+		entries, err = loadFile(d.path)
+	}
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]entry, len(entries))
+	for _, e := range entries {
+		sort.SliceStable(e.Countries, func(i, j int) bool {
+			return e.Countries[i].Probability > e.Countries[j].Probability
+		})
+		index[normalizeKey(e.Name)] = e
+		for _, alias := range e.Aliases {
+			index[normalizeKey(alias)] = e
+		}
+	}
+
+	d.mu.Lock()
+	d.entries = index
+	d.mu.Unlock()
+	return nil
+}
+
+// Size возвращает количество имён в наборе
+func (d *Dataset) Size() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// QueryScript — ключи набора данных хранятся латиницей, как у внешних API
+func (d *Dataset) QueryScript(provider string) string {
+	return api.ScriptLatin
+}
+
+// GetAge возвращает возраст по имени
+func (d *Dataset) GetAge(ctx context.Context, name string) (api.AgeResult, error) {
+	e, err := d.lookup(name)
+	if err != nil {
+		return api.AgeResult{}, err
+	}
+	return api.AgeResult{Age: e.Age, Count: e.Count, Source: Source}, nil
+}
+
+// GetGender возвращает пол по имени
+func (d *Dataset) GetGender(ctx context.Context, name string) (api.GenderResult, error) {
+	e, err := d.lookup(name)
+	if err != nil {
+		return api.GenderResult{}, err
+	}
+	return api.GenderResult{Gender: e.Gender, Probability: e.GenderProbability, Count: e.GenderCount, Source: Source}, nil
+}
+
+// GetNationality возвращает страну по имени с полным распределением
+func (d *Dataset) GetNationality(ctx context.Context, name string) (api.NationalityResult, error) {
+	e, err := d.lookup(name)
+	if err != nil {
+		return api.NationalityResult{}, err
+	}
+	candidates := append([]api.CountryProbability(nil), e.Countries...)
+	chosen, ok := d.strategy.Select(candidates)
+	if !ok {
+		return api.NationalityResult{Candidates: candidates, Source: Source}, nil
+	}
+	return api.NationalityResult{
+		CountryID:   chosen.CountryID,
+		Probability: chosen.Probability,
+		Candidates:  candidates,
+		Source:      Source,
+	}, nil
+}
+
+func (d *Dataset) lookup(name string) (entry, error) {
+	d.mu.RLock()
+	e, ok := d.entries[normalizeKey(name)]
+	d.mu.RUnlock()
+	if !ok {
+		return entry{}, fmt.Errorf("%w: %q", ErrNameNotFound, name)
+	}
+	return e, nil
+}
+
+func normalizeKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func loadFile(path string) ([]entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return parseJSON(f)
+	case ".csv":
+		return parseCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported dataset format %q (expected .json or .csv)", filepath.Ext(path))
+	}
+}
+
+func parseJSON(r io.Reader) ([]entry, error) {
+	var entries []entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse dataset: %w", err)
+	}
+	return entries, nil
+}
+
+// parseCSV читает CSV с заголовком:
+// name,age,count,gender,gender_probability,gender_count,countries[,aliases]
+// где countries — «UA:0.38;RU:0.31», aliases — «tatyana;tatana»
+func parseCSV(r io.Reader) ([]entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dataset: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	entries := make([]entry, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if len(record) != 7 && len(record) != 8 {
+			return nil, fmt.Errorf("dataset line %d: expected 7 or 8 fields, got %d", i+2, len(record))
+		}
+		e := entry{Name: record[0], Gender: record[3]}
+		if e.Age, err = atoiOrZero(record[1]); err != nil {
+			return nil, fmt.Errorf("dataset line %d: invalid age: %w", i+2, err)
+		}
+		if e.Count, err = atoiOrZero(record[2]); err != nil {
+			return nil, fmt.Errorf("dataset line %d: invalid count: %w", i+2, err)
+		}
+		if record[4] != "" {
+			if e.GenderProbability, err = strconv.ParseFloat(record[4], 64); err != nil {
+				return nil, fmt.Errorf("dataset line %d: invalid gender probability: %w", i+2, err)
+			}
+		}
+		if e.GenderCount, err = atoiOrZero(record[5]); err != nil {
+			return nil, fmt.Errorf("dataset line %d: invalid gender count: %w", i+2, err)
+		}
+		if e.Countries, err = parseCountries(record[6]); err != nil {
+			return nil, fmt.Errorf("dataset line %d: %w", i+2, err)
+		}
+		if len(record) == 8 {
+			for _, alias := range strings.Split(record[7], ";") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					e.Aliases = append(e.Aliases, alias)
+				}
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func parseCountries(value string) ([]api.CountryProbability, error) {
+	var countries []api.CountryProbability
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		id, probability, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid country %q", part)
+		}
+		p, err := strconv.ParseFloat(probability, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid country probability %q: %w", part, err)
+		}
+		countries = append(countries, api.CountryProbability{CountryID: id, Probability: p})
+	}
+	return countries, nil
+}
+
+func atoiOrZero(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
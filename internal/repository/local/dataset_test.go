@@ -0,0 +1,99 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
+)
+
+// Имена встроенного набора должны находиться при любой схеме транслитерации,
+// которую можно выбрать для русского языка
+func TestDefaultDatasetResolvesCyrillicNames(t *testing.T) {
+	d, err := NewDataset("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		gender string
+	}{
+		{"Александр", "male"},
+		{"Анна", "female"},
+		{"Дмитрий", "male"},
+		{"Екатерина", "female"},
+		{"Иван", "male"},
+		{"Мария", "female"},
+		{"Михаил", "male"},
+		{"Ольга", "female"},
+		{"Сергей", "male"},
+		{"Татьяна", "female"},
+		{"Владимир", "male"},
+	}
+
+	for _, schemeName := range []string{"simple", "icao", "gost779", "bgn", "iso9"} {
+		scheme, err := translit.Lookup(schemeName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			query := translit.FoldASCII(scheme.Transliterate(tt.name))
+			got, err := d.GetGender(context.Background(), query)
+			if err != nil {
+				t.Errorf("%s: GetGender(%q) for %s error = %v", schemeName, query, tt.name, err)
+				continue
+			}
+			if got.Gender != tt.gender {
+				t.Errorf("%s: GetGender(%q) = %q, want %q", schemeName, query, got.Gender, tt.gender)
+			}
+		}
+	}
+}
+
+func TestDatasetAliasesCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.csv")
+	data := "name,age,count,gender,gender_probability,gender_count,countries,aliases\n" +
+		"tatiana,41,100,female,0.99,120,RU:0.4;UA:0.2,tatyana; tatana\n" +
+		"ivan,49,200,male,0.99,220,RU:0.3,\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDataset(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"tatiana", "Tatyana", " TATANA "} {
+		got, err := d.GetAge(context.Background(), name)
+		if err != nil || got.Age != 41 {
+			t.Errorf("GetAge(%q) = %+v, %v; want age 41", name, got, err)
+		}
+	}
+	if _, err := d.GetAge(context.Background(), "tatjana"); !errors.Is(err, ErrNameNotFound) {
+		t.Errorf("GetAge(%q) error = %v, want %v", "tatjana", err, ErrNameNotFound)
+	}
+	if d.Size() != 4 {
+		t.Errorf("Size() = %d, want 4", d.Size())
+	}
+}
+
+func TestDatasetCSVWithoutAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.csv")
+	data := "name,age,count,gender,gender_probability,gender_count,countries\n" +
+		"ivan,49,200,male,0.99,220,RU:0.3\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDataset(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := d.GetAge(context.Background(), "Ivan"); err != nil || got.Age != 49 {
+		t.Errorf("GetAge(%q) = %+v, %v; want age 49", "Ivan", got, err)
+	}
+}
@@ -0,0 +1,98 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+// ErrAttributeSchemaNotFound возвращается, если у арендатора нет схемы атрибутов
+var ErrAttributeSchemaNotFound = errors.New("attribute schema not found")
+
+// GetAttributeSchema возвращает схему атрибутов арендатора
+func (r *PersonRepository) GetAttributeSchema(ctx context.Context, tenant string) (*model.AttributeSchema, error) {
+	schema := model.AttributeSchema{Tenant: tenant}
+	var raw []byte
+	err := r.db.QueryRowContext(ctx,
+		`SELECT schema, updated_at, updated_by FROM attribute_schemas WHERE tenant = $1`, tenant,
+	).Scan(&raw, &schema.UpdatedAt, &schema.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAttributeSchemaNotFound
+		}
+		return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+	schema.Schema = raw
+	return &schema, nil
+}
+
+// SaveAttributeSchema создаёт или заменяет схему атрибутов арендатора
+func (r *PersonRepository) SaveAttributeSchema(ctx context.Context, schema *model.AttributeSchema) error {
+	actor := auth.Actor(ctx)
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO attribute_schemas (tenant, schema, updated_at, updated_by) VALUES ($1, $2, now(), $3)
+              ON CONFLICT (tenant) DO UPDATE SET schema = EXCLUDED.schema, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+              RETURNING updated_at`,
+		schema.Tenant, []byte(schema.Schema), actor,
+	).Scan(&schema.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save attribute schema: %w", err)
+	}
+	schema.UpdatedBy = &actor
+	return nil
+}
+
+// DeleteAttributeSchema удаляет схему атрибутов арендатора
+func (r *PersonRepository) DeleteAttributeSchema(ctx context.Context, tenant string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM attribute_schemas WHERE tenant = $1`, tenant)
+	if err != nil {
+		return fmt.Errorf("failed to delete attribute schema: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrAttributeSchemaNotFound
+	}
+	return nil
+}
+
+// attributeCandidates возвращает JSON-документы {key: value} для всех типов,
+// которыми может быть value из строки запроса: строка, а также число,
+// логическое значение или null, если value так читается
+func attributeCandidates(key, value string) []string {
+	values := []interface{}{value}
+	if number, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+		values = append(values, number)
+	}
+	switch value {
+	case "true", "false":
+		values = append(values, value == "true")
+	case "null":
+		values = append(values, nil)
+	}
+
+	candidates := make([]string, 0, len(values))
+	for _, v := range values {
+		encoded, _ := json.Marshal(map[string]interface{}{key: v})
+		candidates = append(candidates, string(encoded))
+	}
+	return candidates
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,305 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrContactNotFound возвращается, если у человека нет контакта с таким ID
+	ErrContactNotFound = errors.New("contact not found")
+	// ErrContactExists возвращается при повторном добавлении того же адреса или номера
+	ErrContactExists = errors.New("contact already exists")
+)
+
+// Таблицы контактов
+const (
+	emailsTable    = "person_emails"
+	phonesTable    = "person_phones"
+	addressesTable = "person_addresses"
+)
+
+const emailColumns = `id, person_id, email, label, is_primary, created_at, updated_at`
+
+const phoneColumns = `id, person_id, phone, label, is_primary, created_at, updated_at`
+
+const addressColumns = `id, person_id, label, line1, line2, city, region, postal_code, country,
+              is_primary, created_at, updated_at`
+
+// changeContacts выполняет fn в транзакции: блокирует человека, а после изменения
+// обновляет updated_at/updated_by и пишет журнал
+func (r *PersonRepository) changeContacts(ctx context.Context, personID int64, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockActivePerson(ctx, tx, personID); err != nil {
+		return err
+	}
+	before, err := snapshotPerson(ctx, tx, personID, false)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrContactExists
+		}
+		return err
+	}
+
+	if err := touchPerson(ctx, tx, personID); err != nil {
+		return err
+	}
+	after, err := snapshotPerson(ctx, tx, personID, false)
+	if err != nil {
+		return err
+	}
+	if err := recordHistory(ctx, tx, personID, HistoryUpdate, before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit contact: %w", err)
+	}
+	return nil
+}
+
+// clearPrimary снимает признак основного со всех контактов человека в table, кроме exceptID
+func clearPrimary(ctx context.Context, tx *sql.Tx, table string, personID, exceptID int64) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE `+table+` SET is_primary = false, updated_at = now()
+              WHERE person_id = $1 AND id <> $2 AND is_primary`, personID, exceptID)
+	if err != nil {
+		return fmt.Errorf("failed to clear primary contact: %w", err)
+	}
+	return nil
+}
+
+// deleteContact удаляет контакт человека из table
+func (r *PersonRepository) deleteContact(ctx context.Context, table string, personID, id int64) error {
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = $1 AND person_id = $2`, id, personID)
+		if err != nil {
+			return fmt.Errorf("failed to delete contact: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return ErrContactNotFound
+		}
+		return nil
+	})
+}
+
+// updatedContact переводит отсутствие строки после UPDATE ... RETURNING в ErrContactNotFound
+func updatedContact(err error) error {
+	if err == sql.ErrNoRows {
+		return ErrContactNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save contact: %w", err)
+	}
+	return nil
+}
+
+// CreateEmail добавляет человеку адрес электронной почты
+func (r *PersonRepository) CreateEmail(ctx context.Context, personID int64, email *model.Email) error {
+	email.PersonID = personID
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		if email.Primary {
+			if err := clearPrimary(ctx, tx, emailsTable, personID, 0); err != nil {
+				return err
+			}
+		}
+		return updatedContact(tx.QueryRowContext(ctx,
+			`INSERT INTO person_emails (person_id, email, label, is_primary) VALUES ($1, $2, $3, $4)
+              RETURNING id, created_at, updated_at`,
+			personID, email.Email, email.Label, email.Primary,
+		).Scan(&email.ID, &email.CreatedAt, &email.UpdatedAt))
+	})
+}
+
+// UpdateEmail заменяет адрес электронной почты email.ID
+func (r *PersonRepository) UpdateEmail(ctx context.Context, personID int64, email *model.Email) error {
+	email.PersonID = personID
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		if email.Primary {
+			if err := clearPrimary(ctx, tx, emailsTable, personID, email.ID); err != nil {
+				return err
+			}
+		}
+		return updatedContact(tx.QueryRowContext(ctx,
+			`UPDATE person_emails SET email = $1, label = $2, is_primary = $3, updated_at = now()
+              WHERE id = $4 AND person_id = $5 RETURNING created_at, updated_at`,
+			email.Email, email.Label, email.Primary, email.ID, personID,
+		).Scan(&email.CreatedAt, &email.UpdatedAt))
+	})
+}
+
+// DeleteEmail удаляет адрес электронной почты
+func (r *PersonRepository) DeleteEmail(ctx context.Context, personID, id int64) error {
+	return r.deleteContact(ctx, emailsTable, personID, id)
+}
+
+// CreatePhone добавляет человеку телефон
+func (r *PersonRepository) CreatePhone(ctx context.Context, personID int64, phone *model.Phone) error {
+	phone.PersonID = personID
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		if phone.Primary {
+			if err := clearPrimary(ctx, tx, phonesTable, personID, 0); err != nil {
+				return err
+			}
+		}
+		return updatedContact(tx.QueryRowContext(ctx,
+			`INSERT INTO person_phones (person_id, phone, label, is_primary) VALUES ($1, $2, $3, $4)
+              RETURNING id, created_at, updated_at`,
+			personID, phone.Phone, phone.Label, phone.Primary,
+		).Scan(&phone.ID, &phone.CreatedAt, &phone.UpdatedAt))
+	})
+}
+
+// UpdatePhone заменяет телефон phone.ID
+func (r *PersonRepository) UpdatePhone(ctx context.Context, personID int64, phone *model.Phone) error {
+	phone.PersonID = personID
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		if phone.Primary {
+			if err := clearPrimary(ctx, tx, phonesTable, personID, phone.ID); err != nil {
+				return err
+			}
+		}
+		return updatedContact(tx.QueryRowContext(ctx,
+			`UPDATE person_phones SET phone = $1, label = $2, is_primary = $3, updated_at = now()
+              WHERE id = $4 AND person_id = $5 RETURNING created_at, updated_at`,
+			phone.Phone, phone.Label, phone.Primary, phone.ID, personID,
+		).Scan(&phone.CreatedAt, &phone.UpdatedAt))
+	})
+}
+
+// DeletePhone удаляет телефон
+func (r *PersonRepository) DeletePhone(ctx context.Context, personID, id int64) error {
+	return r.deleteContact(ctx, phonesTable, personID, id)
+}
+
+// CreateAddress добавляет человеку почтовый адрес
+func (r *PersonRepository) CreateAddress(ctx context.Context, personID int64, address *model.Address) error {
+	address.PersonID = personID
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		if address.Primary {
+			if err := clearPrimary(ctx, tx, addressesTable, personID, 0); err != nil {
+				return err
+			}
+		}
+		return updatedContact(tx.QueryRowContext(ctx,
+			`INSERT INTO person_addresses (person_id, label, line1, line2, city, region, postal_code, country, is_primary)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at, updated_at`,
+			personID, address.Label, address.Line1, address.Line2, address.City, address.Region,
+			address.PostalCode, address.Country, address.Primary,
+		).Scan(&address.ID, &address.CreatedAt, &address.UpdatedAt))
+	})
+}
+
+// UpdateAddress заменяет почтовый адрес address.ID
+func (r *PersonRepository) UpdateAddress(ctx context.Context, personID int64, address *model.Address) error {
+	address.PersonID = personID
+	return r.changeContacts(ctx, personID, func(tx *sql.Tx) error {
+		if address.Primary {
+			if err := clearPrimary(ctx, tx, addressesTable, personID, address.ID); err != nil {
+				return err
+			}
+		}
+		return updatedContact(tx.QueryRowContext(ctx,
+			`UPDATE person_addresses SET label = $1, line1 = $2, line2 = $3, city = $4, region = $5,
+              postal_code = $6, country = $7, is_primary = $8, updated_at = now()
+              WHERE id = $9 AND person_id = $10 RETURNING created_at, updated_at`,
+			address.Label, address.Line1, address.Line2, address.City, address.Region,
+			address.PostalCode, address.Country, address.Primary, address.ID, personID,
+		).Scan(&address.CreatedAt, &address.UpdatedAt))
+	})
+}
+
+// DeleteAddress удаляет почтовый адрес
+func (r *PersonRepository) DeleteAddress(ctx context.Context, personID, id int64) error {
+	return r.deleteContact(ctx, addressesTable, personID, id)
+}
+
+// LoadContacts загружает контакты для набора людей; основные контакты идут первыми
+func (r *PersonRepository) LoadContacts(ctx context.Context, ids []int64) (map[int64]*model.Contacts, error) {
+	result := make(map[int64]*model.Contacts, len(ids))
+	for _, id := range ids {
+		result[id] = &model.Contacts{Emails: []model.Email{}, Phones: []model.Phone{}, Addresses: []model.Address{}}
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	err := r.queryContacts(ctx, `SELECT `+emailColumns+` FROM person_emails`, ids, func(rows *sql.Rows) error {
+		var e model.Email
+		if err := rows.Scan(&e.ID, &e.PersonID, &e.Email, &e.Label, &e.Primary, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to scan email: %w", err)
+		}
+		result[e.PersonID].Emails = append(result[e.PersonID].Emails, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.queryContacts(ctx, `SELECT `+phoneColumns+` FROM person_phones`, ids, func(rows *sql.Rows) error {
+		var p model.Phone
+		if err := rows.Scan(&p.ID, &p.PersonID, &p.Phone, &p.Label, &p.Primary, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to scan phone: %w", err)
+		}
+		result[p.PersonID].Phones = append(result[p.PersonID].Phones, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.queryContacts(ctx, `SELECT `+addressColumns+` FROM person_addresses`, ids, func(rows *sql.Rows) error {
+		var a model.Address
+		if err := rows.Scan(&a.ID, &a.PersonID, &a.Label, &a.Line1, &a.Line2, &a.City, &a.Region,
+			&a.PostalCode, &a.Country, &a.Primary, &a.CreatedAt, &a.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to scan address: %w", err)
+		}
+		result[a.PersonID].Addresses = append(result[a.PersonID].Addresses, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// queryContacts выполняет selectFrom для людей ids и передаёт каждую строку в scan
+func (r *PersonRepository) queryContacts(ctx context.Context, selectFrom string, ids []int64, scan func(*sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx,
+		selectFrom+` WHERE person_id = ANY($1) ORDER BY person_id, is_primary DESC, id`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query contacts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+// enrichedColumns — столбцы, которые перезаписывает повторное обогащение
+var enrichedColumns = []string{
+	"age", "age_count", "estimated_age", "estimated_age_at", "gender", "gender_probability", "gender_count", "gender_source",
+	"nationality", "nationality_probability", "provenance", "enrichment_queries",
+}
+
+// ErrPersonChanged возвращается, если человек изменился после того, как были прочитаны
+// данные для обогащения: результат мог бы перезаписать более новые значения
+var ErrPersonChanged = errors.New("person changed during enrichment")
+
+// ApplyEnrichment сохраняет результат повторного обогащения и, если значения
+// изменились, запись в enrichment_history — в одной транзакции. Без изменений
+// обновляется только время обогащения: updated_at и журнал изменений не трогаются,
+// чтобы плановое обновление не выглядело правкой записи. readAt — updated_at
+// записи, по которой считалось обогащение; если с тех пор запись изменилась,
+// возвращается ErrPersonChanged.
+func (r *PersonRepository) ApplyEnrichment(ctx context.Context, id int64, person *model.Person, changes []model.FieldChange, triggeredBy string, readAt time.Time) error {
+	queries, err := json.Marshal(person.EnrichmentQueries)
+	if err != nil {
+		return fmt.Errorf("failed to encode enrichment queries: %w", err)
+	}
+
+	assignments := make([]string, len(enrichedColumns))
+	for i, column := range enrichedColumns {
+		assignments[i] = fmt.Sprintf("%s = $%d", column, i+1)
+	}
+	query := `UPDATE people SET ` + strings.Join(assignments, ", ") +
+		fmt.Sprintf(`, enriched_at = now(), enrich_attempted_at = now(), updated_at = now(), updated_by = $%d`, len(enrichedColumns)+1) +
+		fmt.Sprintf(` WHERE person_id = $%d AND deleted_at IS NULL RETURNING enriched_at, updated_at`, len(enrichedColumns)+2)
+
+	actor := auth.Actor(ctx)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var updatedAt time.Time
+	err = tx.QueryRowContext(ctx,
+		`SELECT updated_at FROM people WHERE person_id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&updatedAt)
+	if err == sql.ErrNoRows {
+		return ErrPersonNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock person: %w", err)
+	}
+	// Ручное изменение между чтением и записью не должно теряться
+	if !updatedAt.Equal(readAt) {
+		return ErrPersonChanged
+	}
+
+	if len(changes) == 0 {
+		err = tx.QueryRowContext(ctx,
+			`UPDATE people SET enriched_at = now(), enrich_attempted_at = now() WHERE person_id = $1 RETURNING enriched_at`,
+			id).Scan(&person.EnrichedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save enrichment: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit enrichment: %w", err)
+		}
+		return nil
+	}
+
+	before, err := snapshotPerson(ctx, tx, id, false)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, query,
+		person.Age,
+		person.AgeCount,
+		person.EstimatedAge,
+		person.EstimatedAgeAt,
+		person.Gender,
+		person.GenderProbability,
+		person.GenderCount,
+		person.GenderSource,
+		person.Nationality,
+		person.NationalityProbability,
+		provenanceValue(person.Provenance),
+		queries,
+		actor,
+		id,
+	).Scan(&person.EnrichedAt, &person.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrPersonNotFound
+		}
+		return fmt.Errorf("failed to save enrichment: %w", err)
+	}
+
+	if err := replaceNationalities(ctx, tx, id, person.Nationalities); err != nil {
+		return err
+	}
+
+	after, err := snapshotPerson(ctx, tx, id, false)
+	if err != nil {
+		return err
+	}
+	if err := recordHistory(ctx, tx, id, HistoryUpdate, before, after); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode enrichment changes: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO enrichment_history (person_id, enriched_at, triggered_by, changes) VALUES ($1, $2, $3, $4)`,
+		id, person.EnrichedAt, triggeredBy, encoded)
+	if err != nil {
+		return fmt.Errorf("failed to save enrichment history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enrichment: %w", err)
+	}
+	person.UpdatedBy = &actor
+
+	return nil
+}
+
+// MarkEnrichAttempt запоминает неудачную попытку обогащения, чтобы планировщик
+// не выбирал этого человека снова до истечения TTL
+func (r *PersonRepository) MarkEnrichAttempt(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE people SET enrich_attempted_at = now() WHERE person_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark enrichment attempt: %w", err)
+	}
+	return nil
+}
+
+// ListStale возвращает до limit ID людей, обогащённых раньше cutoff, начиная с самых старых
+func (r *PersonRepository) ListStale(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT person_id FROM people
+              WHERE deleted_at IS NULL AND COALESCE(enriched_at, '-infinity') < $1 AND COALESCE(enrich_attempted_at, '-infinity') < $1
+              ORDER BY enriched_at NULLS FIRST, person_id LIMIT $2`, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale people: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan person id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// EnrichmentHistory возвращает историю повторных обогащений человека, новые записи первыми
+func (r *PersonRepository) EnrichmentHistory(ctx context.Context, id int64) ([]model.EnrichmentHistoryEntry, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, enriched_at, triggered_by, changes FROM enrichment_history
+              WHERE person_id = $1 ORDER BY enriched_at DESC, id DESC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query enrichment history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []model.EnrichmentHistoryEntry{}
+	for rows.Next() {
+		var entry model.EnrichmentHistoryEntry
+		var changes []byte
+		if err := rows.Scan(&entry.ID, &entry.EnrichedAt, &entry.TriggeredBy, &changes); err != nil {
+			return nil, fmt.Errorf("failed to scan enrichment history: %w", err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode enrichment changes: %w", err)
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/requestid"
+)
+
+// Операции журнала person_history
+const (
+	HistoryInsert   = "insert"
+	HistoryUpdate   = "update"
+	HistoryDelete   = "delete"   // мягкое удаление
+	HistoryRestore  = "restore"  // восстановление после мягкого удаления
+	HistoryPurge    = "purge"    // окончательное удаление по сроку хранения
+	HistorySnapshot = "snapshot" // состояние на момент включения журнала
+)
+
+// personSnapshotExpr собирает строку people p вместе с распределением
+// национальностей, тегами и контактами в JSON с теми же ключами, что и model.Person
+const personSnapshotExpr = `(to_jsonb(p) - 'person_id' - 'enrich_attempted_at') || jsonb_build_object(
+              'id', p.person_id,
+              'nationalities', COALESCE((
+                  SELECT jsonb_agg(jsonb_build_object('country_id', pn.country_id, 'probability', pn.probability, 'rank', pn.rank) ORDER BY pn.rank)
+                  FROM person_nationalities pn WHERE pn.person_id = p.person_id), '[]'::jsonb),
+              'tags', COALESCE((
+                  SELECT jsonb_agg(pt.tag ORDER BY pt.tag) FROM person_tags pt WHERE pt.person_id = p.person_id), '[]'::jsonb),
+              'contacts', jsonb_build_object(
+                  'emails', COALESCE((SELECT jsonb_agg(to_jsonb(e) ORDER BY e.id) FROM person_emails e WHERE e.person_id = p.person_id), '[]'::jsonb),
+                  'phones', COALESCE((SELECT jsonb_agg(to_jsonb(ph) ORDER BY ph.id) FROM person_phones ph WHERE ph.person_id = p.person_id), '[]'::jsonb),
+                  'addresses', COALESCE((SELECT jsonb_agg(to_jsonb(a) ORDER BY a.id) FROM person_addresses a WHERE a.person_id = p.person_id), '[]'::jsonb)))`
+
+const personSnapshotQuery = `SELECT ` + personSnapshotExpr + ` FROM people p WHERE p.person_id = $1`
+
+// snapshotPerson возвращает текущее состояние человека в транзакции tx.
+// С lock строка блокируется до конца транзакции, чтобы снимок «до» совпал с изменяемыми данными.
+func snapshotPerson(ctx context.Context, tx *sql.Tx, id int64, lock bool) ([]byte, error) {
+	if lock {
+		var locked int64
+		err := tx.QueryRowContext(ctx, `SELECT person_id FROM people WHERE person_id = $1 FOR UPDATE`, id).Scan(&locked)
+		if err == sql.ErrNoRows {
+			return nil, ErrPersonNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock person: %w", err)
+		}
+	}
+
+	var snapshot []byte
+	err := tx.QueryRowContext(ctx, personSnapshotQuery, id).Scan(&snapshot)
+	if err == sql.ErrNoRows {
+		return nil, ErrPersonNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot person: %w", err)
+	}
+	return snapshot, nil
+}
+
+// recordHistory добавляет запись в журнал изменений в той же транзакции, что и само изменение
+func recordHistory(ctx context.Context, tx *sql.Tx, id int64, operation string, oldData, newData []byte) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO person_history (person_id, operation, old_data, new_data, actor, request_id)
+              VALUES ($1, $2, $3, $4, $5, $6)`,
+		id, operation, nullJSON(oldData), nullJSON(newData), auth.Actor(ctx), currentRequestID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to record person history: %w", err)
+	}
+	return nil
+}
+
+// currentRequestID возвращает идентификатор запроса из контекста или nil для фоновых задач
+func currentRequestID(ctx context.Context) *string {
+	if value := requestid.FromContext(ctx); value != "" {
+		return &value
+	}
+	return nil
+}
+
+// nullJSON передаёт отсутствующий снимок как NULL, а не пустую строку
+func nullJSON(data []byte) interface{} {
+	if data == nil {
+		return nil
+	}
+	return data
+}
+
+// History возвращает журнал изменений человека, новые записи первыми.
+// Журнал доступен и после удаления записи.
+func (r *PersonRepository) History(ctx context.Context, id int64) ([]model.PersonHistoryEntry, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, person_id, operation, old_data, new_data, actor, request_id, changed_at
+              FROM person_history WHERE person_id = $1 ORDER BY changed_at DESC, id DESC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query person history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []model.PersonHistoryEntry{}
+	for rows.Next() {
+		var entry model.PersonHistoryEntry
+		var oldData, newData []byte
+		err := rows.Scan(&entry.ID, &entry.PersonID, &entry.Operation, &oldData, &newData,
+			&entry.Actor, &entry.RequestID, &entry.ChangedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan person history: %w", err)
+		}
+		if oldData != nil {
+			if err := json.Unmarshal(oldData, &entry.OldData); err != nil {
+				return nil, fmt.Errorf("failed to decode history data: %w", err)
+			}
+		}
+		if newData != nil {
+			if err := json.Unmarshal(newData, &entry.NewData); err != nil {
+				return nil, fmt.Errorf("failed to decode history data: %w", err)
+			}
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetAsOf восстанавливает состояние человека на момент at по журналу изменений.
+// Если в этот момент записи не было (ещё не создана, уже удалена или журнал
+// тогда не вёлся), возвращается ErrPersonNotFound.
+func (r *PersonRepository) GetAsOf(ctx context.Context, id int64, at time.Time) (*model.Person, error) {
+	var operation string
+	var data []byte
+	err := r.db.QueryRowContext(ctx,
+		`SELECT operation, new_data FROM person_history
+              WHERE person_id = $1 AND changed_at <= $2 ORDER BY changed_at DESC, id DESC LIMIT 1`,
+		id, at).Scan(&operation, &data)
+	if err == sql.ErrNoRows {
+		return nil, ErrPersonNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query person history: %w", err)
+	}
+	if operation == HistoryPurge || data == nil {
+		return nil, ErrPersonNotFound
+	}
+
+	var person model.Person
+	if err := json.Unmarshal(data, &person); err != nil {
+		return nil, fmt.Errorf("failed to decode history data: %w", err)
+	}
+	if person.DeletedAt != nil {
+		return nil, ErrPersonNotFound
+	}
+	return &person, nil
+}
@@ -3,11 +3,169 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/lib/pq"
 )
 
+// ErrPersonNotFound возвращается, если человека с таким ID нет
+var ErrPersonNotFound = errors.New("person not found")
+
+// ErrPersonNotDeleted возвращается при восстановлении записи, которая не удалена
+var ErrPersonNotDeleted = errors.New("person is not deleted")
+
+// personAgeExpr — возраст на сегодня по дате или году рождения, иначе сохранённый
+// (оценка agify или ручное значение). Используется и в выборке, и в фильтрах по возрасту.
+const personAgeExpr = `(CASE
+              WHEN birth_date IS NOT NULL THEN date_part('year', age(current_date, birth_date))::int
+              WHEN birth_year IS NOT NULL THEN date_part('year', current_date)::int - birth_year
+              ELSE age END)`
+
+// personColumns — столбцы people в порядке, ожидаемом scanPerson
+const personColumns = `person_id, name, surname, patronymic, name_original, surname_original,
+              patronymic_original, canonical_name, script, language, ` + personAgeExpr + ` AS age, age_count,
+              birth_date, birth_year, estimated_age, estimated_age_at, gender,
+              gender_probability, gender_count, gender_source, nationality, nationality_probability,
+              enrichment_queries, provenance, attributes, enriched_at,
+              created_at, updated_at, created_by, updated_by, deleted_at, deleted_by`
+
+// personWritableColumns — столбцы, которые пишутся при создании и обновлении,
+// в порядке personValues
+var personWritableColumns = []string{
+	"name", "surname", "patronymic", "name_original", "surname_original",
+	"patronymic_original", "canonical_name", "script", "language", "age", "age_count",
+	"birth_date", "birth_year", "estimated_age", "estimated_age_at", "gender",
+	"gender_probability", "gender_count", "gender_source", "nationality", "nationality_probability",
+	"provenance", "attributes",
+}
+
+func personValues(person *model.Person) []interface{} {
+	return []interface{}{
+		person.Name,
+		person.Surname,
+		person.Patronymic,
+		person.NameOriginal,
+		person.SurnameOriginal,
+		person.PatronymicOriginal,
+		person.CanonicalName,
+		person.Script,
+		person.Language,
+		person.Age,
+		person.AgeCount,
+		person.BirthDate,
+		person.BirthYear,
+		person.EstimatedAge,
+		person.EstimatedAgeAt,
+		person.Gender,
+		person.GenderProbability,
+		person.GenderCount,
+		person.GenderSource,
+		person.Nationality,
+		person.NationalityProbability,
+		provenanceValue(person.Provenance),
+		attributesValue(person.Attributes),
+	}
+}
+
+// provenanceValue сохраняет происхождение полей в JSONB-столбец provenance
+type provenanceValue map[string]model.FieldProvenance
+
+func (p provenanceValue) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]model.FieldProvenance(p))
+}
+
+// attributesValue сохраняет атрибуты в JSONB-столбец attributes
+type attributesValue map[string]interface{}
+
+func (a attributesValue) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]interface{}(a))
+}
+
+// placeholders возвращает «$from, $from+1, ...» для n параметров
+func placeholders(from, n int) string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = fmt.Sprintf("$%d", from+i)
+	}
+	return strings.Join(result, ", ")
+}
+
+// rowScanner — общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPerson(row rowScanner, person *model.Person) error {
+	var queries, provenance, attributes []byte
+	var birthDate *time.Time
+	err := row.Scan(
+		&person.ID,
+		&person.Name,
+		&person.Surname,
+		&person.Patronymic,
+		&person.NameOriginal,
+		&person.SurnameOriginal,
+		&person.PatronymicOriginal,
+		&person.CanonicalName,
+		&person.Script,
+		&person.Language,
+		&person.Age,
+		&person.AgeCount,
+		&birthDate,
+		&person.BirthYear,
+		&person.EstimatedAge,
+		&person.EstimatedAgeAt,
+		&person.Gender,
+		&person.GenderProbability,
+		&person.GenderCount,
+		&person.GenderSource,
+		&person.Nationality,
+		&person.NationalityProbability,
+		&queries,
+		&provenance,
+		&attributes,
+		&person.EnrichedAt,
+		&person.CreatedAt,
+		&person.UpdatedAt,
+		&person.CreatedBy,
+		&person.UpdatedBy,
+		&person.DeletedAt,
+		&person.DeletedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if birthDate != nil {
+		value := birthDate.Format(time.DateOnly)
+		person.BirthDate = &value
+	}
+	if queries != nil {
+		if err := json.Unmarshal(queries, &person.EnrichmentQueries); err != nil {
+			return fmt.Errorf("failed to decode enrichment queries: %w", err)
+		}
+	}
+	if err := json.Unmarshal(provenance, &person.Provenance); err != nil {
+		return fmt.Errorf("failed to decode provenance: %w", err)
+	}
+	if err := json.Unmarshal(attributes, &person.Attributes); err != nil {
+		return fmt.Errorf("failed to decode attributes: %w", err)
+	}
+	return nil
+}
+
 type PersonRepository struct {
 	db *sql.DB
 }
@@ -17,56 +175,114 @@ func NewPersonRepository(db *sql.DB) *PersonRepository {
 }
 
 func (r *PersonRepository) Create(ctx context.Context, person *model.Person) (int64, error) {
-	query := `INSERT INTO people (name, surname, patronymic, age, gender, nationality) 
-              VALUES ($1, $2, $3, $4, $5, $6) RETURNING person_id`
+	columns := append(personWritableColumns[:len(personWritableColumns):len(personWritableColumns)], "enrichment_queries", "enriched_at", "created_by", "updated_by")
+	query := `INSERT INTO people (` + strings.Join(columns, ", ") + `) 
+              VALUES (` + placeholders(1, len(columns)) + `) RETURNING person_id, created_at, updated_at`
+
+	queries, err := json.Marshal(person.EnrichmentQueries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode enrichment queries: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
 	var id int64
-	err := r.db.QueryRowContext(ctx, query,
-		person.Name, person.Surname, person.Patronymic,
-		person.Age, person.Gender, person.Nationality).Scan(&id)
+	actor := auth.Actor(ctx)
+	args := append(personValues(person), queries, person.EnrichedAt, actor, actor)
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&id, &person.CreatedAt, &person.UpdatedAt)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to create person: %w", err)
 	}
 
+	if err := replaceNationalities(ctx, tx, id, person.Nationalities); err != nil {
+		return 0, err
+	}
+	if err := replaceTags(ctx, tx, id, person.Tags); err != nil {
+		return 0, err
+	}
+
+	snapshot, err := snapshotPerson(ctx, tx, id, false)
+	if err != nil {
+		return 0, err
+	}
+	if err := recordHistory(ctx, tx, id, HistoryInsert, nil, snapshot); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit person: %w", err)
+	}
+
+	person.CreatedBy = &actor
+	person.UpdatedBy = &actor
+
 	return id, nil
 }
 
+// GetByID возвращает человека по ID; мягко удалённые записи не возвращаются
 func (r *PersonRepository) GetByID(ctx context.Context, id int64) (*model.Person, error) {
-	query := `SELECT person_id, name, surname, patronymic, age, gender, nationality 
+	return r.getByID(ctx, id, false)
+}
+
+// GetByIDIncludingDeleted возвращает человека по ID, в том числе мягко удалённого
+func (r *PersonRepository) GetByIDIncludingDeleted(ctx context.Context, id int64) (*model.Person, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *PersonRepository) getByID(ctx context.Context, id int64, includeDeleted bool) (*model.Person, error) {
+	query := `SELECT ` + personColumns + ` 
               FROM people WHERE person_id = $1`
+	if !includeDeleted {
+		query += ` AND deleted_at IS NULL`
+	}
 
 	var person model.Person
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&person.ID,
-		&person.Name,
-		&person.Surname,
-		&person.Patronymic,
-		&person.Age,
-		&person.Gender,
-		&person.Nationality,
-	)
+	err := scanPerson(r.db.QueryRowContext(ctx, query, id), &person)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("person not found")
+			return nil, ErrPersonNotFound
 		}
 		return nil, fmt.Errorf("failed to get person: %w", err)
 	}
 
+	nationalities, err := r.loadNationalities(ctx, []int64{person.ID})
+	if err != nil {
+		return nil, err
+	}
+	person.Nationalities = nationalities[person.ID]
+
+	tags, err := r.loadTags(ctx, []int64{person.ID})
+	if err != nil {
+		return nil, err
+	}
+	person.Tags = tags[person.ID]
+
 	return &person, nil
 }
 
-func (r *PersonRepository) GetAll(ctx context.Context, filterParams model.FilterParams) ([]model.Person, error) {
-	query := `SELECT person_id, name, surname, patronymic, age, gender, nationality FROM people WHERE 1=1`
+// personFilter строит условия WHERE по параметрам фильтрации списка людей;
+// аргументы нумеруются с $1
+func personFilter(filterParams model.FilterParams) (string, []interface{}) {
+	query := ` WHERE 1=1`
 	var args []interface{}
 	argID := 1 // номер аргумента для $n
 
+	if !filterParams.IncludeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+
 	// Фильтры
 	if filterParams.Name != nil {
-		query += fmt.Sprintf(" AND name ILIKE $%d", argID)
-		args = append(args, "%"+*filterParams.Name+"%")
-		argID++
+		// Совпадение по введённому имени, полной форме или полным формам уменьшительного
+		query += fmt.Sprintf(" AND (name ILIKE $%d OR canonical_name ILIKE $%d OR canonical_name = ANY($%d))", argID, argID, argID+1)
+		args = append(args, "%"+*filterParams.Name+"%", pq.Array(filterParams.CanonicalNames))
+		argID += 2
 	}
 	if filterParams.Surname != nil {
 		query += fmt.Sprintf(" AND surname ILIKE $%d", argID)
@@ -74,12 +290,12 @@ func (r *PersonRepository) GetAll(ctx context.Context, filterParams model.Filter
 		argID++
 	}
 	if filterParams.AgeMin != nil {
-		query += fmt.Sprintf(" AND age >= $%d", argID)
+		query += fmt.Sprintf(" AND "+personAgeExpr+" >= $%d", argID)
 		args = append(args, *filterParams.AgeMin)
 		argID++
 	}
 	if filterParams.AgeMax != nil {
-		query += fmt.Sprintf(" AND age <= $%d", argID)
+		query += fmt.Sprintf(" AND "+personAgeExpr+" <= $%d", argID)
 		args = append(args, *filterParams.AgeMax)
 		argID++
 	}
@@ -88,11 +304,89 @@ func (r *PersonRepository) GetAll(ctx context.Context, filterParams model.Filter
 		args = append(args, *filterParams.Gender)
 		argID++
 	}
-	if filterParams.Nationality != nil {
+	if filterParams.Nationality != nil && filterParams.NationalityCandidateMin != nil {
+		// Совпадение с любым кандидатом распределения выше порога
+		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM person_nationalities pn
+              WHERE pn.person_id = people.person_id AND pn.country_id = $%d AND pn.probability >= $%d)`, argID, argID+1)
+		args = append(args, *filterParams.Nationality, *filterParams.NationalityCandidateMin)
+		argID += 2
+	} else if filterParams.Nationality != nil {
 		query += fmt.Sprintf(" AND nationality = $%d", argID)
 		args = append(args, *filterParams.Nationality)
 		argID++
 	}
+	if filterParams.Script != nil {
+		query += fmt.Sprintf(" AND script = $%d", argID)
+		args = append(args, *filterParams.Script)
+		argID++
+	}
+	if filterParams.GenderProbabilityMin != nil {
+		query += fmt.Sprintf(" AND gender_probability >= $%d", argID)
+		args = append(args, *filterParams.GenderProbabilityMin)
+		argID++
+	}
+	if filterParams.NationalityProbabilityMin != nil {
+		query += fmt.Sprintf(" AND nationality_probability >= $%d", argID)
+		args = append(args, *filterParams.NationalityProbabilityMin)
+		argID++
+	}
+	if filterParams.AgeCountMin != nil {
+		query += fmt.Sprintf(" AND age_count >= $%d", argID)
+		args = append(args, *filterParams.AgeCountMin)
+		argID++
+	}
+	if filterParams.UpdatedSince != nil {
+		query += fmt.Sprintf(" AND updated_at >= $%d", argID)
+		args = append(args, *filterParams.UpdatedSince)
+		argID++
+	}
+	if filterParams.CreatedFrom != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argID)
+		args = append(args, *filterParams.CreatedFrom)
+		argID++
+	}
+	if filterParams.CreatedTo != nil {
+		query += fmt.Sprintf(" AND created_at <= $%d", argID)
+		args = append(args, *filterParams.CreatedTo)
+		argID++
+	}
+	for _, key := range sortedKeys(filterParams.Attributes) {
+		// Значение из строки запроса может означать строку, число или логическое значение;
+		// каждое сравнение — attributes @> ..., чтобы использовался GIN-индекс
+		var conditions []string
+		for _, candidate := range attributeCandidates(key, filterParams.Attributes[key]) {
+			conditions = append(conditions, fmt.Sprintf("attributes @> $%d::jsonb", argID))
+			args = append(args, candidate)
+			argID++
+		}
+		query += " AND (" + strings.Join(conditions, " OR ") + ")"
+	}
+	if len(filterParams.Tags) > 0 {
+		// Все перечисленные теги
+		query += fmt.Sprintf(` AND (SELECT count(*) FROM person_tags pt
+              WHERE pt.person_id = people.person_id AND pt.tag = ANY($%d)) = $%d`, argID, argID+1)
+		args = append(args, pq.Array(filterParams.Tags), len(filterParams.Tags))
+		argID += 2
+	}
+	if len(filterParams.TagsAny) > 0 {
+		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM person_tags pt
+              WHERE pt.person_id = people.person_id AND pt.tag = ANY($%d))`, argID)
+		args = append(args, pq.Array(filterParams.TagsAny))
+		argID++
+	}
+	if len(filterParams.TagsNone) > 0 {
+		query += fmt.Sprintf(` AND NOT EXISTS (SELECT 1 FROM person_tags pt
+              WHERE pt.person_id = people.person_id AND pt.tag = ANY($%d))`, argID)
+		args = append(args, pq.Array(filterParams.TagsNone))
+	}
+
+	return query, args
+}
+
+func (r *PersonRepository) GetAll(ctx context.Context, filterParams model.FilterParams) ([]model.Person, error) {
+	where, args := personFilter(filterParams)
+	query := `SELECT ` + personColumns + ` FROM people` + where
+	argID := len(args) + 1 // номер аргумента для $n
 
 	// Пагинация
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argID, argID+1)
@@ -110,15 +404,7 @@ func (r *PersonRepository) GetAll(ctx context.Context, filterParams model.Filter
 	var people []model.Person
 	for rows.Next() {
 		var person model.Person
-		if err := rows.Scan(
-			&person.ID,
-			&person.Name,
-			&person.Surname,
-			&person.Patronymic,
-			&person.Age,
-			&person.Gender,
-			&person.Nationality,
-		); err != nil {
+		if err := scanPerson(rows, &person); err != nil {
 			return nil, fmt.Errorf("failed to scan person: %w", err)
 		}
 		people = append(people, person)
@@ -128,28 +414,47 @@ func (r *PersonRepository) GetAll(ctx context.Context, filterParams model.Filter
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
+	ids := make([]int64, len(people))
+	for i := range people {
+		ids[i] = people[i].ID
+	}
+	nationalities, err := r.loadNationalities(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.loadTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range people {
+		people[i].Nationalities = nationalities[people[i].ID]
+		people[i].Tags = tags[people[i].ID]
+	}
+
 	return people, nil
 }
 
 func (r *PersonRepository) Update(ctx context.Context, id int64, person *model.Person) error {
-	query := `UPDATE people SET 
-              name = $1, 
-              surname = $2, 
-              patronymic = $3, 
-              age = $4, 
-              gender = $5, 
-              nationality = $6 
-              WHERE person_id = $7`
-
-	result, err := r.db.ExecContext(ctx, query,
-		person.Name,
-		person.Surname,
-		person.Patronymic,
-		person.Age,
-		person.Gender,
-		person.Nationality,
-		id,
-	)
+	assignments := make([]string, len(personWritableColumns))
+	for i, column := range personWritableColumns {
+		assignments[i] = fmt.Sprintf("%s = $%d", column, i+1)
+	}
+	query := `UPDATE people SET ` + strings.Join(assignments, ", ") +
+		fmt.Sprintf(`, updated_at = now(), updated_by = $%d WHERE person_id = $%d AND deleted_at IS NULL`,
+			len(personWritableColumns)+1, len(personWritableColumns)+2)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotPerson(ctx, tx, id, true)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query, append(personValues(person), auth.Actor(ctx), id)...)
 
 	if err != nil {
 		return fmt.Errorf("failed to update person: %w", err)
@@ -161,16 +466,54 @@ func (r *PersonRepository) Update(ctx context.Context, id int64, person *model.P
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("person not found")
+		return ErrPersonNotFound
+	}
+
+	// Распределение и теги заменяются, только если переданы явно
+	if person.Nationalities != nil {
+		if err := replaceNationalities(ctx, tx, id, person.Nationalities); err != nil {
+			return err
+		}
+	}
+	if person.Tags != nil {
+		if err := replaceTags(ctx, tx, id, person.Tags); err != nil {
+			return err
+		}
+	}
+
+	after, err := snapshotPerson(ctx, tx, id, false)
+	if err != nil {
+		return err
+	}
+	if err := recordHistory(ctx, tx, id, HistoryUpdate, before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit person update: %w", err)
 	}
 
 	return nil
 }
 
+// Delete мягко удаляет человека: запись скрывается из выборок, но её можно
+// восстановить до окончательного удаления через Purge
 func (r *PersonRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM people WHERE person_id = $1`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotPerson(ctx, tx, id, true)
+	if err != nil {
+		return err
+	}
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	actor := auth.Actor(ctx)
+	result, err := tx.ExecContext(ctx,
+		`UPDATE people SET deleted_at = now(), deleted_by = $1, updated_at = now(), updated_by = $1
+              WHERE person_id = $2 AND deleted_at IS NULL`, actor, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete person: %w", err)
 	}
@@ -181,8 +524,156 @@ func (r *PersonRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("person not found")
+		return ErrPersonNotFound
+	}
+
+	after, err := snapshotPerson(ctx, tx, id, false)
+	if err != nil {
+		return err
+	}
+	if err := recordHistory(ctx, tx, id, HistoryDelete, before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit person deletion: %w", err)
 	}
 
 	return nil
 }
+
+// Restore отменяет мягкое удаление
+func (r *PersonRepository) Restore(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotPerson(ctx, tx, id, true)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE people SET deleted_at = NULL, deleted_by = NULL, updated_at = now(), updated_by = $1
+              WHERE person_id = $2 AND deleted_at IS NOT NULL`, auth.Actor(ctx), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore person: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPersonNotDeleted
+	}
+
+	after, err := snapshotPerson(ctx, tx, id, false)
+	if err != nil {
+		return err
+	}
+	if err := recordHistory(ctx, tx, id, HistoryRestore, before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit person restore: %w", err)
+	}
+
+	return nil
+}
+
+// Purge окончательно удаляет записи, мягко удалённые раньше cutoff, и возвращает их количество.
+// Последнее состояние каждой записи сохраняется в журнале с операцией purge.
+func (r *PersonRepository) Purge(ctx context.Context, cutoff time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем строки, чтобы одновременное восстановление не попало между журналом и удалением
+	if _, err := tx.ExecContext(ctx, `SELECT person_id FROM people WHERE deleted_at < $1 FOR UPDATE`, cutoff); err != nil {
+		return 0, fmt.Errorf("failed to lock purged people: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO person_history (person_id, operation, old_data, actor, request_id)
+              SELECT p.person_id, $2, `+personSnapshotExpr+`, $3, $4
+              FROM people p WHERE p.deleted_at < $1`,
+		cutoff, HistoryPurge, auth.Actor(ctx), currentRequestID(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record purge history: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM people WHERE deleted_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge people: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	return purged, nil
+}
+
+// replaceNationalities перезаписывает распределение национальностей человека
+func replaceNationalities(ctx context.Context, tx *sql.Tx, personID int64, candidates []model.NationalityCandidate) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM person_nationalities WHERE person_id = $1`, personID); err != nil {
+		return fmt.Errorf("failed to clear nationalities: %w", err)
+	}
+
+	for _, c := range candidates {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO person_nationalities (person_id, country_id, probability, rank) VALUES ($1, $2, $3, $4)`,
+			personID, c.CountryID, c.Probability, c.Rank)
+		if err != nil {
+			return fmt.Errorf("failed to save nationality: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadNationalities загружает распределения национальностей для набора людей
+func (r *PersonRepository) loadNationalities(ctx context.Context, ids []int64) (map[int64][]model.NationalityCandidate, error) {
+	result := make(map[int64][]model.NationalityCandidate, len(ids))
+	for _, id := range ids {
+		result[id] = []model.NationalityCandidate{}
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT person_id, country_id, probability, rank FROM person_nationalities
+              WHERE person_id = ANY($1) ORDER BY person_id, rank`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nationalities: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var personID int64
+		var c model.NationalityCandidate
+		if err := rows.Scan(&personID, &c.CountryID, &c.Probability, &c.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan nationality: %w", err)
+		}
+		result[personID] = append(result[personID], c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,279 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrRelationNotFound возвращается, если у человека нет связи с таким ID
+	ErrRelationNotFound = errors.New("relation not found")
+	// ErrRelationExists возвращается при повторном добавлении той же связи
+	ErrRelationExists = errors.New("relation already exists")
+	// ErrRelationConflict возвращается, если связь противоречит уже существующим
+	ErrRelationConflict = errors.New("relation conflicts with existing relations")
+)
+
+// maxParents — больше двух родителей у человека быть не может
+const maxParents = 2
+
+const relationColumns = `r.id, r.person_id, r.related_id, r.type, r.created_at, r.created_by,
+              p.person_id, p.name, p.surname, p.patronymic, p.gender, ` + personAgeExpr
+
+// relationsQuery выбирает связи человека $1 вместе с данными другой стороны;
+// связи с удалёнными людьми скрыты
+const relationsQuery = `SELECT ` + relationColumns + ` FROM person_relations r
+              JOIN people p ON p.person_id = CASE WHEN r.person_id = $1 THEN r.related_id ELSE r.person_id END
+              WHERE (r.person_id = $1 OR r.related_id = $1) AND p.deleted_at IS NULL`
+
+// relationRole возвращает, кем другая сторона связи приходится человеку personID
+func relationRole(personID, from, to int64, relationType string) string {
+	switch {
+	case relationType == model.RelationParent && from == personID:
+		return model.RoleChild
+	case relationType == model.RelationManager && from == personID:
+		return model.RoleReport
+	default:
+		return relationType
+	}
+}
+
+func scanRelation(row rowScanner, personID int64) (model.Relation, error) {
+	var rel model.Relation
+	var from, to int64
+	err := row.Scan(&rel.ID, &from, &to, &rel.Type, &rel.CreatedAt, &rel.CreatedBy,
+		&rel.Related.ID, &rel.Related.Name, &rel.Related.Surname, &rel.Related.Patronymic,
+		&rel.Related.Gender, &rel.Related.Age)
+	if err != nil {
+		return rel, err
+	}
+	rel.Role = relationRole(personID, from, to, rel.Type)
+	return rel, nil
+}
+
+// checkRelation проверяет, что направленная связь edge не создаёт цикл
+// (человек не может быть предком или руководителем самого себя) и что
+// у ребёнка не больше двух неудалённых родителей
+func checkRelation(ctx context.Context, tx *sql.Tx, edge *model.RelationEdge) error {
+	if edge.Type != model.RelationParent && edge.Type != model.RelationManager {
+		return nil
+	}
+
+	var cycle bool
+	err := tx.QueryRowContext(ctx,
+		`WITH RECURSIVE below(id) AS (
+                  SELECT related_id FROM person_relations WHERE person_id = $1 AND type = $3
+                  UNION
+                  SELECT r.related_id FROM person_relations r JOIN below b ON r.person_id = b.id WHERE r.type = $3)
+              SELECT EXISTS (SELECT 1 FROM below WHERE id = $2)`,
+		edge.To, edge.From, edge.Type).Scan(&cycle)
+	if err != nil {
+		return fmt.Errorf("failed to check relation cycle: %w", err)
+	}
+	if cycle {
+		return fmt.Errorf("%w: %s relation would create a cycle", ErrRelationConflict, edge.Type)
+	}
+
+	if edge.Type != model.RelationParent {
+		return nil
+	}
+	// Удалённые родители не учитываются: связи с ними скрыты и не мешают указать нового
+	var parents int
+	err = tx.QueryRowContext(ctx,
+		`SELECT count(*) FROM person_relations r
+              JOIN people p ON p.person_id = r.person_id AND p.deleted_at IS NULL
+              WHERE r.related_id = $1 AND r.type = 'parent'`, edge.To).Scan(&parents)
+	if err != nil {
+		return fmt.Errorf("failed to count parents: %w", err)
+	}
+	if parents >= maxParents {
+		return fmt.Errorf("%w: person %d already has %d parents", ErrRelationConflict, edge.To, maxParents)
+	}
+	return nil
+}
+
+// CreateRelation сохраняет связь edge и возвращает её с точки зрения человека personID.
+// Оба человека должны существовать и не быть удалены.
+func (r *PersonRepository) CreateRelation(ctx context.Context, personID int64, edge *model.RelationEdge) (*model.Relation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировки берутся в порядке ID, чтобы встречные связи проверялись по очереди
+	first, second := edge.From, edge.To
+	if first > second {
+		first, second = second, first
+	}
+	for _, id := range []int64{first, second} {
+		if err := lockActivePerson(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkRelation(ctx, tx, edge); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO person_relations (person_id, related_id, type, created_by) VALUES ($1, $2, $3, $4) RETURNING id`,
+		edge.From, edge.To, edge.Type, auth.Actor(ctx)).Scan(&edge.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, ErrRelationExists
+		}
+		return nil, fmt.Errorf("failed to create relation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit relation: %w", err)
+	}
+
+	rel, err := scanRelation(r.db.QueryRowContext(ctx, relationsQuery+` AND r.id = $2`, personID, edge.ID), personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relation: %w", err)
+	}
+	return &rel, nil
+}
+
+// DeleteRelation удаляет связь id, в которой участвует человек personID
+func (r *PersonRepository) DeleteRelation(ctx context.Context, personID, id int64) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM person_relations WHERE id = $1 AND $2 IN (person_id, related_id)`, id, personID)
+	if err != nil {
+		return fmt.Errorf("failed to delete relation: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrRelationNotFound
+	}
+	return nil
+}
+
+// ListRelations возвращает связи человека с неудалёнными людьми
+func (r *PersonRepository) ListRelations(ctx context.Context, personID int64) ([]model.Relation, error) {
+	rows, err := r.db.QueryContext(ctx, relationsQuery+` ORDER BY r.type, r.id`, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relations: %w", err)
+	}
+	defer rows.Close()
+
+	relations := []model.Relation{}
+	for rows.Next() {
+		rel, err := scanRelation(rows, personID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan relation: %w", err)
+		}
+		relations = append(relations, rel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return relations, nil
+}
+
+// RelationEdges возвращает связи типов types, в которых участвует кто-то из ids;
+// связи с удалёнными людьми пропускаются
+func (r *PersonRepository) RelationEdges(ctx context.Context, ids []int64, types []string) ([]model.RelationEdge, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT r.id, r.person_id, r.related_id, r.type FROM person_relations r
+              JOIN people a ON a.person_id = r.person_id AND a.deleted_at IS NULL
+              JOIN people b ON b.person_id = r.related_id AND b.deleted_at IS NULL
+              WHERE (r.person_id = ANY($1) OR r.related_id = ANY($1)) AND r.type = ANY($2)
+              ORDER BY r.id`, pq.Array(ids), pq.Array(types))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relations: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []model.RelationEdge
+	for rows.Next() {
+		var e model.RelationEdge
+		if err := rows.Scan(&e.ID, &e.From, &e.To, &e.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan relation: %w", err)
+		}
+		edges = append(edges, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return edges, nil
+}
+
+// PersonSummaries загружает краткие данные набора людей
+func (r *PersonRepository) PersonSummaries(ctx context.Context, ids []int64) (map[int64]model.PersonSummary, error) {
+	result := make(map[int64]model.PersonSummary, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT person_id, name, surname, patronymic, gender, `+personAgeExpr+`
+              FROM people WHERE person_id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query people: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s model.PersonSummary
+		if err := rows.Scan(&s.ID, &s.Name, &s.Surname, &s.Patronymic, &s.Gender, &s.Age); err != nil {
+			return nil, fmt.Errorf("failed to scan person: %w", err)
+		}
+		result[s.ID] = s
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// FatherCandidates ищет неудалённых людей, кроме женщин, с одним из имён names
+// (или полных форм имени) и одной из фамилий surnames. Сравнение без учёта
+// регистра и различия «е»/«ё»; names и surnames должны быть в нижнем регистре и с «е».
+func (r *PersonRepository) FatherCandidates(ctx context.Context, excludeID int64, names, surnames []string, limit int) ([]model.Person, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+personColumns+` FROM people
+              WHERE deleted_at IS NULL AND person_id <> $1
+                AND (replace(lower(name), 'ё', 'е') = ANY($2) OR replace(lower(canonical_name), 'ё', 'е') = ANY($2))
+                AND replace(lower(surname), 'ё', 'е') = ANY($3)
+                AND gender IS DISTINCT FROM 'female'
+              ORDER BY person_id LIMIT $4`,
+		excludeID, pq.Array(names), pq.Array(surnames), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query father candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var people []model.Person
+	for rows.Next() {
+		var person model.Person
+		if err := scanPerson(rows, &person); err != nil {
+			return nil, fmt.Errorf("failed to scan person: %w", err)
+		}
+		people = append(people, person)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return people, nil
+}
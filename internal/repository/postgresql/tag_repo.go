@@ -0,0 +1,233 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/lib/pq"
+)
+
+// replaceTags приводит теги человека к tags; уже существующие теги сохраняют время добавления
+func replaceTags(ctx context.Context, tx *sql.Tx, personID int64, tags []string) error {
+	_, err := tx.ExecContext(ctx,
+		`DELETE FROM person_tags WHERE person_id = $1 AND NOT (tag = ANY($2))`, personID, pq.Array(tags))
+	if err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	_, err = addTags(ctx, tx, personID, tags)
+	return err
+}
+
+// addTags добавляет недостающие теги и возвращает, сколько добавлено
+func addTags(ctx context.Context, tx *sql.Tx, personID int64, tags []string) (int64, error) {
+	if len(tags) == 0 {
+		return 0, nil
+	}
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO person_tags (person_id, tag, added_by) SELECT $1, unnest($2::text[]), $3
+              ON CONFLICT (person_id, tag) DO NOTHING`,
+		personID, pq.Array(tags), auth.Actor(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to add tags: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// removeTags удаляет теги и возвращает, сколько удалено
+func removeTags(ctx context.Context, tx *sql.Tx, personID int64, tags []string) (int64, error) {
+	if len(tags) == 0 {
+		return 0, nil
+	}
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM person_tags WHERE person_id = $1 AND tag = ANY($2)`, personID, pq.Array(tags))
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove tags: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// changeTags добавляет и удаляет теги человека в транзакции tx. Если теги изменились,
+// обновляет updated_at/updated_by и пишет журнал. Удалённые люди — ErrPersonNotFound.
+func changeTags(ctx context.Context, tx *sql.Tx, personID int64, add, remove []string) (bool, error) {
+	if err := lockActivePerson(ctx, tx, personID); err != nil {
+		return false, err
+	}
+
+	before, err := snapshotPerson(ctx, tx, personID, false)
+	if err != nil {
+		return false, err
+	}
+
+	added, err := addTags(ctx, tx, personID, add)
+	if err != nil {
+		return false, err
+	}
+	removed, err := removeTags(ctx, tx, personID, remove)
+	if err != nil {
+		return false, err
+	}
+	if added == 0 && removed == 0 {
+		return false, nil
+	}
+
+	if err := touchPerson(ctx, tx, personID); err != nil {
+		return false, err
+	}
+
+	after, err := snapshotPerson(ctx, tx, personID, false)
+	if err != nil {
+		return false, err
+	}
+	if err := recordHistory(ctx, tx, personID, HistoryUpdate, before, after); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// lockActivePerson блокирует строку человека до конца транзакции; удалённые — ErrPersonNotFound
+func lockActivePerson(ctx context.Context, tx *sql.Tx, personID int64) error {
+	var locked int64
+	err := tx.QueryRowContext(ctx,
+		`SELECT person_id FROM people WHERE person_id = $1 AND deleted_at IS NULL FOR UPDATE`, personID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return ErrPersonNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock person: %w", err)
+	}
+	return nil
+}
+
+// touchPerson отмечает изменение связанных данных человека в updated_at/updated_by
+func touchPerson(ctx context.Context, tx *sql.Tx, personID int64) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE people SET updated_at = now(), updated_by = $1 WHERE person_id = $2`, auth.Actor(ctx), personID)
+	if err != nil {
+		return fmt.Errorf("failed to update person: %w", err)
+	}
+	return nil
+}
+
+// ChangeTags добавляет и удаляет теги человека и возвращает его теги после изменения
+func (r *PersonRepository) ChangeTags(ctx context.Context, id int64, add, remove []string) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := changeTags(ctx, tx, id, add, remove); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tags: %w", err)
+	}
+
+	tags, err := r.loadTags(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	return tags[id], nil
+}
+
+// BulkChangeTags добавляет и удаляет теги у набора людей в одной транзакции.
+// Отсутствующие и удалённые люди пропускаются и возвращаются в NotFound.
+func (r *PersonRepository) BulkChangeTags(ctx context.Context, ids []int64, add, remove []string) (*model.BulkTagResult, error) {
+	// Блокировки берутся в порядке ID, чтобы параллельные запросы не взаимоблокировались
+	sorted := append([]int64(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &model.BulkTagResult{Updated: []int64{}, NotFound: []int64{}}
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		changed, err := changeTags(ctx, tx, id, add, remove)
+		switch {
+		case err == ErrPersonNotFound:
+			result.NotFound = append(result.NotFound, id)
+		case err != nil:
+			return nil, err
+		case changed:
+			result.Updated = append(result.Updated, id)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tags: %w", err)
+	}
+	return result, nil
+}
+
+// TagCounts возвращает число людей с каждым тегом среди тех, кто проходит фильтры
+// (пагинация не учитывается), начиная с самых частых
+func (r *PersonRepository) TagCounts(ctx context.Context, filterParams model.FilterParams) ([]model.TagCount, error) {
+	where, args := personFilter(filterParams)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT pt.tag, count(*) FROM person_tags pt
+              WHERE pt.person_id IN (SELECT person_id FROM people`+where+`)
+              GROUP BY pt.tag ORDER BY count(*) DESC, pt.tag`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []model.TagCount{}
+	for rows.Next() {
+		var c model.TagCount
+		if err := rows.Scan(&c.Tag, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// loadTags загружает теги для набора людей
+func (r *PersonRepository) loadTags(ctx context.Context, ids []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(ids))
+	for _, id := range ids {
+		result[id] = []string{}
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT person_id, tag FROM person_tags WHERE person_id = ANY($1) ORDER BY person_id, tag`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var personID int64
+		var tag string
+		if err := rows.Scan(&personID, &tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		result[personID] = append(result[personID], tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return result, nil
+}
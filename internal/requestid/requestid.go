@@ -0,0 +1,50 @@
+// Package requestid присваивает каждому запросу идентификатор для журналов и аудита
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Header — заголовок с идентификатором запроса; входящее значение сохраняется,
+// чтобы цепочку можно было проследить через несколько сервисов
+const Header = "X-Request-ID"
+
+// maxLength ограничивает входящий идентификатор
+const maxLength = 128
+
+type requestIDKey struct{}
+
+// WithID возвращает контекст с идентификатором запроса
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext возвращает идентификатор запроса или пустую строку
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware берёт идентификатор из заголовка Header или создаёт новый
+// и возвращает его в ответе
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if id == "" || len(id) > maxLength {
+			id = newID()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+	})
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
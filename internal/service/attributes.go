@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/postgresql"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/jsonschema"
+)
+
+// ErrInvalidAttributes возвращается, если атрибуты не соответствуют схеме арендатора
+var ErrInvalidAttributes = errors.New("invalid attributes")
+
+// validateAttributes проверяет атрибуты по схеме арендатора из ctx.
+// Без схемы допустимы любые атрибуты.
+func (s *PersonService) validateAttributes(ctx context.Context, attributes map[string]interface{}) error {
+	stored, err := s.personRepo.GetAttributeSchema(ctx, auth.Tenant(ctx))
+	if errors.Is(err, postgresql.ErrAttributeSchemaNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	schema, err := jsonschema.Compile(stored.Schema)
+	if err != nil {
+		return fmt.Errorf("stored attribute schema for tenant %q: %w", stored.Tenant, err)
+	}
+
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	if err := schema.Validate(attributes); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAttributes, err)
+	}
+	return nil
+}
+
+// GetAttributeSchema возвращает схему атрибутов арендатора из ctx
+func (s *PersonService) GetAttributeSchema(ctx context.Context) (*model.AttributeSchema, error) {
+	return s.personRepo.GetAttributeSchema(ctx, auth.Tenant(ctx))
+}
+
+// SaveAttributeSchema проверяет и сохраняет схему атрибутов арендатора из ctx.
+// Уже сохранённые атрибуты по новой схеме не перепроверяются.
+func (s *PersonService) SaveAttributeSchema(ctx context.Context, raw json.RawMessage) (*model.AttributeSchema, error) {
+	if _, err := jsonschema.Compile(raw); err != nil {
+		return nil, err
+	}
+
+	schema := &model.AttributeSchema{Tenant: auth.Tenant(ctx), Schema: raw}
+	if err := s.personRepo.SaveAttributeSchema(ctx, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// DeleteAttributeSchema удаляет схему атрибутов арендатора из ctx
+func (s *PersonService) DeleteAttributeSchema(ctx context.Context) error {
+	return s.personRepo.DeleteAttributeSchema(ctx, auth.Tenant(ctx))
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+// ErrInvalidBirthDate возвращается для даты рождения в будущем или
+// противоречащих друг другу даты и года рождения
+var ErrInvalidBirthDate = errors.New("invalid birth date")
+
+// hasBirthDate сообщает, что возраст человека вычисляется по дате или году рождения
+func hasBirthDate(person *model.Person) bool {
+	return person.BirthDate != nil || person.BirthYear != nil
+}
+
+// applyBirthDate проверяет дату и год рождения, заполняет год по дате и
+// вычисляет возраст на момент now. Без даты и года ничего не меняет.
+func applyBirthDate(person *model.Person, now time.Time) error {
+	if person.BirthDate != nil {
+		birth, err := time.Parse(time.DateOnly, *person.BirthDate)
+		if err != nil {
+			return fmt.Errorf("%w: expected YYYY-MM-DD", ErrInvalidBirthDate)
+		}
+		if birth.After(now) {
+			return fmt.Errorf("%w: %s is in the future", ErrInvalidBirthDate, *person.BirthDate)
+		}
+		if person.BirthYear != nil && *person.BirthYear != birth.Year() {
+			return fmt.Errorf("%w: birth_year %d does not match birth_date %s", ErrInvalidBirthDate, *person.BirthYear, *person.BirthDate)
+		}
+		year := birth.Year()
+		age := ageOn(birth, now)
+		person.BirthYear = &year
+		person.Age = &age
+		return nil
+	}
+
+	if person.BirthYear != nil {
+		if *person.BirthYear > now.Year() {
+			return fmt.Errorf("%w: birth_year %d is in the future", ErrInvalidBirthDate, *person.BirthYear)
+		}
+		// Без даты возраст — разница лет, как и в personAgeExpr
+		age := now.Year() - *person.BirthYear
+		person.Age = &age
+	}
+	return nil
+}
+
+// ageOn возвращает число полных лет на дату now
+func ageOn(birth, now time.Time) int {
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
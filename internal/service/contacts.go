@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/phone"
+)
+
+// DefaultPhoneCountryCode — код страны для номеров, записанных без международного префикса
+const DefaultPhoneCountryCode = "7"
+
+// contacts возвращает контакты человека; удалённые люди — ErrPersonNotFound
+func (s *PersonService) contacts(ctx context.Context, personID int64) (*model.Contacts, error) {
+	if _, err := s.personRepo.GetByID(ctx, personID); err != nil {
+		return nil, err
+	}
+	contacts, err := s.personRepo.LoadContacts(ctx, []int64{personID})
+	if err != nil {
+		return nil, err
+	}
+	return contacts[personID], nil
+}
+
+// ExpandContacts встраивает контакты в данные людей (expand=contacts)
+func (s *PersonService) ExpandContacts(ctx context.Context, people ...*model.Person) error {
+	ids := make([]int64, len(people))
+	for i, person := range people {
+		ids[i] = person.ID
+	}
+	contacts, err := s.personRepo.LoadContacts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, person := range people {
+		person.Contacts = contacts[person.ID]
+	}
+	return nil
+}
+
+// ListEmails возвращает адреса электронной почты человека, основной первым
+func (s *PersonService) ListEmails(ctx context.Context, personID int64) ([]model.Email, error) {
+	contacts, err := s.contacts(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	return contacts.Emails, nil
+}
+
+// SaveEmail добавляет адрес электронной почты (id = 0) или заменяет существующий
+func (s *PersonService) SaveEmail(ctx context.Context, personID, id int64, input model.EmailInput) (*model.Email, error) {
+	email := &model.Email{
+		ID:      id,
+		Email:   normalizeEmail(input.Email),
+		Label:   trimOptional(input.Label),
+		Primary: input.Primary,
+	}
+	save := s.personRepo.UpdateEmail
+	if id == 0 {
+		save = s.personRepo.CreateEmail
+	}
+	if err := save(ctx, personID, email); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// DeleteEmail удаляет адрес электронной почты
+func (s *PersonService) DeleteEmail(ctx context.Context, personID, id int64) error {
+	return s.personRepo.DeleteEmail(ctx, personID, id)
+}
+
+// ListPhones возвращает телефоны человека, основной первым
+func (s *PersonService) ListPhones(ctx context.Context, personID int64) ([]model.Phone, error) {
+	contacts, err := s.contacts(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	return contacts.Phones, nil
+}
+
+// SavePhone добавляет телефон (id = 0) или заменяет существующий; номер приводится к E.164
+func (s *PersonService) SavePhone(ctx context.Context, personID, id int64, input model.PhoneInput) (*model.Phone, error) {
+	number, err := phone.Normalize(input.Phone, s.phoneCountryCode)
+	if err != nil {
+		return nil, err
+	}
+	p := &model.Phone{
+		ID:      id,
+		Phone:   number,
+		Label:   trimOptional(input.Label),
+		Primary: input.Primary,
+	}
+	save := s.personRepo.UpdatePhone
+	if id == 0 {
+		save = s.personRepo.CreatePhone
+	}
+	if err := save(ctx, personID, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DeletePhone удаляет телефон
+func (s *PersonService) DeletePhone(ctx context.Context, personID, id int64) error {
+	return s.personRepo.DeletePhone(ctx, personID, id)
+}
+
+// ListAddresses возвращает почтовые адреса человека, основной первым
+func (s *PersonService) ListAddresses(ctx context.Context, personID int64) ([]model.Address, error) {
+	contacts, err := s.contacts(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	return contacts.Addresses, nil
+}
+
+// SaveAddress добавляет почтовый адрес (id = 0) или заменяет существующий
+func (s *PersonService) SaveAddress(ctx context.Context, personID, id int64, input model.AddressInput) (*model.Address, error) {
+	address := &model.Address{
+		ID:         id,
+		Label:      trimOptional(input.Label),
+		Line1:      strings.TrimSpace(input.Line1),
+		Line2:      trimOptional(input.Line2),
+		City:       strings.TrimSpace(input.City),
+		Region:     trimOptional(input.Region),
+		PostalCode: trimOptional(input.PostalCode),
+		Country:    strings.ToUpper(input.Country),
+		Primary:    input.Primary,
+	}
+	save := s.personRepo.UpdateAddress
+	if id == 0 {
+		save = s.personRepo.CreateAddress
+	}
+	if err := save(ctx, personID, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// DeleteAddress удаляет почтовый адрес
+func (s *PersonService) DeleteAddress(ctx context.Context, personID, id int64) error {
+	return s.personRepo.DeleteAddress(ctx, personID, id)
+}
+
+// normalizeEmail обрезает пробелы и приводит домен к нижнему регистру;
+// регистр имени ящика сохраняется, уникальность проверяется без учёта регистра
+func normalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		email = email[:at+1] + strings.ToLower(email[at+1:])
+	}
+	return email
+}
+
+// trimOptional обрезает пробелы; пустая строка становится nil
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/local"
+)
+
+// ErrNoDataset возвращается при перезагрузке, если локальный набор данных не подключён
+var ErrNoDataset = errors.New("local dataset is not configured")
+
+// Источники обогащения
+const (
+	EnrichmentAPI      = "api"            // только внешние API (по умолчанию)
+	EnrichmentLocal    = "local"          // только локальный набор данных
+	EnrichmentAPILocal = "api_then_local" // API, при ошибке — локальный набор
+	EnrichmentLocalAPI = "local_then_api" // локальный набор, если имени нет — API
+)
+
+// Enricher — источник возраста, пола и национальности по имени.
+// Реализуется *api.APIClient и *local.Dataset.
+type Enricher interface {
+	GetAge(ctx context.Context, name string) (api.AgeResult, error)
+	GetGender(ctx context.Context, name string) (api.GenderResult, error)
+	GetNationality(ctx context.Context, name string) (api.NationalityResult, error)
+	QueryScript(provider string) string
+}
+
+var (
+	_ Enricher = (*api.APIClient)(nil)
+	_ Enricher = (*local.Dataset)(nil)
+)
+
+// fallbackEnricher обращается к fallback, если primary вернул ошибку, для которой
+// retry возвращает true (nil — при любой ошибке)
+type fallbackEnricher struct {
+	primary  Enricher
+	fallback Enricher
+	retry    func(error) bool
+}
+
+func (f fallbackEnricher) shouldFallback(ctx context.Context, err error) bool {
+	return err != nil && ctx.Err() == nil && (f.retry == nil || f.retry(err))
+}
+
+func (f fallbackEnricher) GetAge(ctx context.Context, name string) (api.AgeResult, error) {
+	result, err := f.primary.GetAge(ctx, name)
+	if !f.shouldFallback(ctx, err) {
+		return result, err
+	}
+	return f.fallback.GetAge(ctx, name)
+}
+
+func (f fallbackEnricher) GetGender(ctx context.Context, name string) (api.GenderResult, error) {
+	result, err := f.primary.GetGender(ctx, name)
+	if !f.shouldFallback(ctx, err) {
+		return result, err
+	}
+	return f.fallback.GetGender(ctx, name)
+}
+
+func (f fallbackEnricher) GetNationality(ctx context.Context, name string) (api.NationalityResult, error) {
+	result, err := f.primary.GetNationality(ctx, name)
+	if !f.shouldFallback(ctx, err) {
+		return result, err
+	}
+	return f.fallback.GetNationality(ctx, name)
+}
+
+// nameNotFound — для local_then_api: к API обращаемся, только если имени нет в наборе
+func nameNotFound(err error) bool {
+	return errors.Is(err, local.ErrNameNotFound)
+}
+
+// datasetEnricher — локальный набор как последний источник: для имени, которого
+// нет в наборе, возвращается пустой результат, как у внешних API для неизвестных имён
+type datasetEnricher struct {
+	*local.Dataset
+}
+
+func (d datasetEnricher) GetAge(ctx context.Context, name string) (api.AgeResult, error) {
+	result, err := d.Dataset.GetAge(ctx, name)
+	if nameNotFound(err) {
+		return api.AgeResult{Source: local.Source}, nil
+	}
+	return result, err
+}
+
+func (d datasetEnricher) GetGender(ctx context.Context, name string) (api.GenderResult, error) {
+	result, err := d.Dataset.GetGender(ctx, name)
+	if nameNotFound(err) {
+		return api.GenderResult{Source: local.Source}, nil
+	}
+	return result, err
+}
+
+func (d datasetEnricher) GetNationality(ctx context.Context, name string) (api.NationalityResult, error) {
+	result, err := d.Dataset.GetNationality(ctx, name)
+	if nameNotFound(err) {
+		return api.NationalityResult{Source: local.Source}, nil
+	}
+	return result, err
+}
+
+func (f fallbackEnricher) QueryScript(provider string) string {
+	return f.primary.QueryScript(provider)
+}
+
+// newEnricher собирает источник обогащения по настройке source
+func newEnricher(source string, apiClient *api.APIClient, dataset *local.Dataset) (Enricher, error) {
+	needAPI := source != EnrichmentLocal
+	needDataset := source != EnrichmentAPI && source != ""
+	if needAPI && apiClient == nil {
+		return nil, fmt.Errorf("enrichment source %q requires API client", source)
+	}
+	if needDataset && dataset == nil {
+		return nil, fmt.Errorf("enrichment source %q requires local dataset", source)
+	}
+
+	switch source {
+	case "", EnrichmentAPI:
+		return apiClient, nil
+	case EnrichmentLocal:
+		return datasetEnricher{dataset}, nil
+	case EnrichmentAPILocal:
+		return fallbackEnricher{primary: apiClient, fallback: datasetEnricher{dataset}}, nil
+	case EnrichmentLocalAPI:
+		return fallbackEnricher{primary: dataset, fallback: apiClient, retry: nameNotFound}, nil
+	default:
+		return nil, fmt.Errorf("unknown enrichment source %q", source)
+	}
+}
+
+// ReloadDataset перечитывает файл локального набора данных
+func (s *PersonService) ReloadDataset() (int, error) {
+	if s.dataset == nil {
+		return 0, ErrNoDataset
+	}
+	if err := s.dataset.Reload(); err != nil {
+		return 0, fmt.Errorf("failed to reload dataset: %w", err)
+	}
+	return s.dataset.Size(), nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/local"
+)
+
+func TestLocalEnricherUnknownName(t *testing.T) {
+	dataset, err := local.NewDataset("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enricher, err := newEnricher(EnrichmentLocal, nil, dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	age, err := enricher.GetAge(ctx, "Zzyzx")
+	if err != nil || age.Age != 0 || age.Source != local.Source {
+		t.Errorf("GetAge(unknown) = %+v, %v; want empty result", age, err)
+	}
+	gender, err := enricher.GetGender(ctx, "Zzyzx")
+	if err != nil || gender.Gender != "" {
+		t.Errorf("GetGender(unknown) = %+v, %v; want empty result", gender, err)
+	}
+	nationality, err := enricher.GetNationality(ctx, "Zzyzx")
+	if err != nil || nationality.CountryID != "" || len(nationality.Candidates) != 0 {
+		t.Errorf("GetNationality(unknown) = %+v, %v; want empty result", nationality, err)
+	}
+
+	if gender, err := enricher.GetGender(ctx, "ivan"); err != nil || gender.Gender != "male" {
+		t.Errorf("GetGender(ivan) = %+v, %v; want male", gender, err)
+	}
+}
+
+func TestLocalThenAPIFallsBackOnlyForUnknownNames(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"name":"zzyzx","age":33,"count":7}`))
+	}))
+	defer server.Close()
+
+	dataset, err := local.NewDataset("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := api.NewAPIClient(api.Config{Agify: api.ProviderConfig{URL: server.URL}})
+	enricher, err := newEnricher(EnrichmentLocalAPI, client, dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	age, err := enricher.GetAge(ctx, "ivan")
+	if err != nil || age.Source != local.Source || calls.Load() != 0 {
+		t.Errorf("GetAge(ivan) = %+v, %v after %d API calls; want dataset value", age, err, calls.Load())
+	}
+	age, err = enricher.GetAge(ctx, "zzyzx")
+	if err != nil || age.Age != 33 || calls.Load() != 1 {
+		t.Errorf("GetAge(zzyzx) = %+v, %v after %d API calls; want API value", age, err, calls.Load())
+	}
+}
+
+// failingEnricher возвращает err на любой запрос
+type failingEnricher struct{ err error }
+
+func (f failingEnricher) GetAge(context.Context, string) (api.AgeResult, error) {
+	return api.AgeResult{}, f.err
+}
+
+func (f failingEnricher) GetGender(context.Context, string) (api.GenderResult, error) {
+	return api.GenderResult{}, f.err
+}
+
+func (f failingEnricher) GetNationality(context.Context, string) (api.NationalityResult, error) {
+	return api.NationalityResult{}, f.err
+}
+
+func (f failingEnricher) QueryScript(string) string { return api.ScriptLatin }
+
+func TestFallbackEnricherRetry(t *testing.T) {
+	boom := errors.New("boom")
+	fallback := failingEnricher{err: errors.New("fallback called")}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		primary error
+		retry   func(error) bool
+		want    error
+	}{
+		{"any error falls back", boom, nil, fallback.err},
+		{"not found falls back", local.ErrNameNotFound, nameNotFound, fallback.err},
+		{"other error is returned", boom, nameNotFound, boom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fallbackEnricher{primary: failingEnricher{err: tt.primary}, fallback: fallback, retry: tt.retry}
+			if _, err := f.GetAge(ctx, "ivan"); !errors.Is(err, tt.want) {
+				t.Errorf("GetAge() error = %v, want %v", err, tt.want)
+			}
+			if _, err := f.GetGender(ctx, "ivan"); !errors.Is(err, tt.want) {
+				t.Errorf("GetGender() error = %v, want %v", err, tt.want)
+			}
+			if _, err := f.GetNationality(ctx, "ivan"); !errors.Is(err, tt.want) {
+				t.Errorf("GetNationality() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
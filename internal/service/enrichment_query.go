@@ -0,0 +1,58 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
+)
+
+// enrichmentProviders — провайдеры, к которым обращается Create
+var enrichmentProviders = []string{api.ProviderAgify, api.ProviderGenderize, api.ProviderNationalize}
+
+// enrichmentQueries приводит имя к виду, который ожидает каждый провайдер:
+// латиница (ASCII) для ScriptLatin, исходная письменность для ScriptNative
+func (s *PersonService) enrichmentQueries(input model.PersonInput) map[string]string {
+	name := strings.TrimSpace(input.Name)
+	latin := name
+	// Транслитерируем только кириллицу: латиница уходит как есть,
+	// для остальных письменностей схем нет
+	if translit.DetectScript(name) == translit.ScriptCyrillic {
+		latin = translit.FoldASCII(s.schemeFor(input).Transliterate(name))
+	}
+
+	queries := make(map[string]string, len(enrichmentProviders))
+	for _, provider := range enrichmentProviders {
+		if s.enricher.QueryScript(provider) == api.ScriptNative {
+			queries[provider] = name
+		} else {
+			queries[provider] = latin
+		}
+	}
+	return queries
+}
+
+// schemeFor выбирает схему транслитерации по языку: заданному явно или
+// определённому по буквам ФИО. Для русского используется настроенная схема.
+func (s *PersonService) schemeFor(input model.PersonInput) *translit.Scheme {
+	lang := ""
+	if input.Language != nil {
+		lang = *input.Language
+	} else {
+		fullName := input.Name + " " + input.Surname
+		if input.Patronymic != nil {
+			fullName += " " + *input.Patronymic
+		}
+		lang = translit.DetectLanguage(fullName)
+	}
+
+	if lang == translit.LangRussian {
+		return s.scheme
+	}
+	scheme, err := translit.ForLanguage(lang)
+	if err != nil {
+		return s.scheme
+	}
+	return scheme
+}
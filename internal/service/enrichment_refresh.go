@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/postgresql"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/logger"
+)
+
+// Кто запустил повторное обогащение
+const (
+	TriggerRequest   = "request"   // POST /api/persons/{id}/enrich
+	TriggerScheduler = "scheduler" // фоновое обновление устаревших данных
+)
+
+// ReenrichOptions — параметры повторного обогащения
+type ReenrichOptions struct {
+	DryRun  bool   // только посчитать изменения, ничего не сохранять
+	Force   bool   // перезаписать и значения, введённые вручную
+	Trigger string // TriggerRequest или TriggerScheduler
+}
+
+// maxReenrichAttempts — сколько раз Reenrich повторяет обогащение, если человек
+// изменился, пока шли запросы к провайдерам
+const maxReenrichAttempts = 3
+
+// Reenrich заново запрашивает возраст, пол и национальность человека и
+// сохраняет изменившиеся значения вместе с записью в истории. Если запись
+// изменилась во время запросов к провайдерам (например, PATCH задал значение
+// вручную), обогащение считается заново по новой версии.
+func (s *PersonService) Reenrich(ctx context.Context, id int64, opts ReenrichOptions) (*model.EnrichmentResult, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := s.reenrichOnce(ctx, id, opts)
+		if !errors.Is(err, postgresql.ErrPersonChanged) || attempt == maxReenrichAttempts {
+			return result, err
+		}
+	}
+}
+
+func (s *PersonService) reenrichOnce(ctx context.Context, id int64, opts ReenrichOptions) (*model.EnrichmentResult, error) {
+	current, err := s.personRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	person := clonePerson(current)
+	name := person.Name
+	if person.CanonicalName != nil {
+		name = *person.CanonicalName
+	}
+	queries := s.enrichmentQueries(model.PersonInput{
+		Name:       name,
+		Surname:    person.Surname,
+		Patronymic: person.Patronymic,
+		Language:   person.Language,
+	})
+	person.EnrichmentQueries = queries
+
+	if err := s.enrich(ctx, s.enricher, person, queries, opts.Force); err != nil {
+		return nil, err
+	}
+
+	changes := enrichmentChanges(current, person)
+	if !opts.DryRun {
+		if err := s.personRepo.ApplyEnrichment(ctx, id, person, changes, opts.Trigger, current.UpdatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.EnrichmentResult{DryRun: opts.DryRun, Changes: changes, Person: person}, nil
+}
+
+// EnrichmentHistory возвращает историю повторных обогащений человека
+func (s *PersonService) EnrichmentHistory(ctx context.Context, id int64) ([]model.EnrichmentHistoryEntry, error) {
+	if _, err := s.personRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.personRepo.EnrichmentHistory(ctx, id)
+}
+
+// RefreshStale повторно обогащает одну партию людей, данные которых старше ttl.
+// Останавливается на исчерпании квоты; прочие ошибки откладывают человека до следующего TTL.
+func (s *PersonService) RefreshStale(ctx context.Context, ttl time.Duration, batchSize int) (refreshed, failed int, err error) {
+	ids, err := s.personRepo.ListStale(ctx, time.Now().Add(-ttl), batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, id := range ids {
+		_, err := s.Reenrich(ctx, id, ReenrichOptions{Trigger: TriggerScheduler})
+		switch {
+		case err == nil:
+			refreshed++
+		case errors.Is(err, api.ErrQuotaExhausted), ctx.Err() != nil:
+			return refreshed, failed, err
+		default:
+			failed++
+			if err := s.personRepo.MarkEnrichAttempt(ctx, id); err != nil {
+				return refreshed, failed, err
+			}
+		}
+	}
+	return refreshed, failed, nil
+}
+
+// SchedulerActor — автор изменений, сделанных планировщиком
+const SchedulerActor = "system:scheduler"
+
+// RefreshConfig — настройки фонового обновления данных
+type RefreshConfig struct {
+	TTL       time.Duration // возраст данных, после которого они обновляются
+	Interval  time.Duration // пауза между партиями
+	BatchSize int           // людей в партии
+}
+
+// RefreshScheduler периодически обновляет устаревшие данные партиями.
+// Частота запросов дополнительно ограничивается настройками API-клиента.
+type RefreshScheduler struct {
+	service *PersonService
+	logger  logger.Logger
+	cfg     RefreshConfig
+}
+
+func NewRefreshScheduler(service *PersonService, logger logger.Logger, cfg RefreshConfig) *RefreshScheduler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	return &RefreshScheduler{service: service, logger: logger, cfg: cfg}
+}
+
+// Run обрабатывает партии до отмены ctx
+func (r *RefreshScheduler) Run(ctx context.Context) {
+	r.logger.Info("Enrichment refresh scheduler started",
+		"ttl", r.cfg.TTL.String(), "interval", r.cfg.Interval.String(), "batch_size", r.cfg.BatchSize)
+
+	// Изменения планировщика записываются от имени системы
+	ctx = auth.WithPrincipal(ctx, auth.Principal{Subject: SchedulerActor})
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Enrichment refresh scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+
+		refreshed, failed, err := r.service.RefreshStale(ctx, r.cfg.TTL, r.cfg.BatchSize)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("Enrichment refresh batch interrupted", err)
+		}
+		if refreshed > 0 || failed > 0 {
+			r.logger.Info("Enrichment refresh batch done", "refreshed", refreshed, "failed", failed)
+		}
+	}
+}
+
+// clonePerson копирует человека так, чтобы обогащение копии не меняло оригинал
+func clonePerson(person *model.Person) *model.Person {
+	clone := *person
+	if person.Provenance != nil {
+		clone.Provenance = make(map[string]model.FieldProvenance, len(person.Provenance))
+		for field, provenance := range person.Provenance {
+			clone.Provenance[field] = provenance
+		}
+	}
+	return &clone
+}
+
+// enrichmentChanges сравнивает обогащаемые поля до и после обогащения
+func enrichmentChanges(before, after *model.Person) []model.FieldChange {
+	changes := []model.FieldChange{}
+	add := func(field string, old, new interface{}) {
+		if !reflect.DeepEqual(old, new) {
+			changes = append(changes, model.FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("age", deref(before.Age), deref(after.Age))
+	add("age_count", deref(before.AgeCount), deref(after.AgeCount))
+	add("estimated_age", deref(before.EstimatedAge), deref(after.EstimatedAge))
+	add("gender", deref(before.Gender), deref(after.Gender))
+	add("gender_probability", deref(before.GenderProbability), deref(after.GenderProbability))
+	add("gender_count", deref(before.GenderCount), deref(after.GenderCount))
+	add("gender_source", deref(before.GenderSource), deref(after.GenderSource))
+	add("nationality", deref(before.Nationality), deref(after.Nationality))
+	add("nationality_probability", deref(before.NationalityProbability), deref(after.NationalityProbability))
+	add("nationalities", nonEmpty(before.Nationalities), nonEmpty(after.Nationalities))
+	return changes
+}
+
+// deref возвращает значение по указателю или nil, чтобы сравнивать и сериализовать значения, а не адреса
+func deref[T any](p *T) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func nonEmpty(candidates []model.NationalityCandidate) interface{} {
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates
+}
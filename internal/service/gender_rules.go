@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
+)
+
+// Порядок определения пола: правила по отчеству и фамилии и/или genderize
+const (
+	GenderRulesFirst = "rules_first" // правила, при неудаче — API (по умолчанию)
+	GenderAPIFirst   = "api_first"   // API, если пол неизвестен — правила
+	GenderRulesOnly  = "rules_only"  // только правила, без запросов к API
+	GenderAPIOnly    = "api_only"    // только API
+)
+
+// Источник значения пола
+const (
+	GenderSourceRules  = "rules"
+	GenderSourceAPI    = "api"
+	GenderSourceManual = "manual"
+)
+
+// Уверенность правил: отчество почти однозначно, фамилия — чуть менее
+const (
+	patronymicRuleProbability = 0.99
+	surnameRuleProbability    = 0.9
+)
+
+// genderSuffixes — окончания, по которым определяется пол; женские
+// проверяются первыми, так как «-ична» длиннее «-ич»
+type genderSuffixes struct {
+	female []string
+	male   []string
+}
+
+var patronymicSuffixes = genderSuffixes{
+	female: []string{"овна", "евна", "ична", "кызы", "қызы"},
+	male:   []string{"ович", "евич", "ич", "оглы", "улы", "ұлы"},
+}
+
+var surnameSuffixes = genderSuffixes{
+	female: []string{"ова", "ева", "ёва", "ина", "ына", "ская", "цкая", "ая"},
+	male:   []string{"ов", "ев", "ёв", "ин", "ын", "ский", "цкий", "ской", "цкой"},
+}
+
+// Окончания в латинской транслитерации. Они встречаются и в неславянских именах
+// (Casanova, Ulrich), поэтому применяются только к транслитерации с кириллицы.
+var latinPatronymicSuffixes = genderSuffixes{
+	female: []string{"ovna", "evna", "ichna", "kyzy", "qyzy", "gyzy"},
+	male:   []string{"ovich", "evich", "ich", "ogly", "uly"},
+}
+
+var latinSurnameSuffixes = genderSuffixes{
+	female: []string{"ova", "eva", "skaya", "tskaya"},
+	male:   []string{"ov", "ev", "skiy", "skii", "sky", "tskiy"},
+}
+
+func (g genderSuffixes) match(word string) (string, bool) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if word == "" {
+		return "", false
+	}
+	for _, suffix := range g.female {
+		if strings.HasSuffix(word, suffix) && len(word) > len(suffix) {
+			return "female", true
+		}
+	}
+	for _, suffix := range g.male {
+		if strings.HasSuffix(word, suffix) && len(word) > len(suffix) {
+			return "male", true
+		}
+	}
+	return "", false
+}
+
+// InferGender определяет пол по окончаниям отчества (-ович/-овна, -оглы/-кызы)
+// и фамилии (-ов/-ова, -ский/-ская). Отчество приоритетнее фамилии.
+// Латинские окончания (-ovich, -ova) учитываются только с transliterated —
+// когда латиница в ФИО является транслитерацией с кириллицы.
+func InferGender(surname string, patronymic *string, transliterated bool) (gender string, probability float64, ok bool) {
+	patronymicRules := []genderSuffixes{patronymicSuffixes}
+	surnameRules := []genderSuffixes{surnameSuffixes}
+	if transliterated {
+		patronymicRules = append(patronymicRules, latinPatronymicSuffixes)
+		surnameRules = append(surnameRules, latinSurnameSuffixes)
+	}
+
+	if patronymic != nil {
+		for _, rules := range patronymicRules {
+			if gender, ok := rules.match(*patronymic); ok {
+				return gender, patronymicRuleProbability, true
+			}
+		}
+	}
+	for _, rules := range surnameRules {
+		if gender, ok := rules.match(surname); ok {
+			return gender, surnameRuleProbability, true
+		}
+	}
+	return "", 0, false
+}
+
+// transliteratedName сообщает, что латиница в ФИО — транслитерация с кириллицы:
+// язык ФИО (ru, uk, be, kk) задан явно или в одном из полей есть кириллица
+func transliteratedName(person *model.Person) bool {
+	if person.Language != nil {
+		return true
+	}
+	fields := []string{person.Name, person.Surname}
+	if person.Patronymic != nil {
+		fields = append(fields, *person.Patronymic)
+	}
+	for _, field := range fields {
+		if translit.DetectScript(field) == translit.ScriptCyrillic {
+			return true
+		}
+	}
+	return false
+}
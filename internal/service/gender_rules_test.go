@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+func TestInferGender(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name           string
+		surname        string
+		patronymic     *string
+		transliterated bool
+		wantGender     string
+		wantOK         bool
+	}{
+		// Кириллица
+		{"cyrillic patronymic male", "Смирнов", ptr("Иванович"), false, "male", true},
+		{"cyrillic patronymic female", "Смирнова", ptr("Ивановна"), false, "female", true},
+		{"patronymic wins over surname", "Смирнова", ptr("Иванович"), false, "male", true},
+		{"cyrillic surname only", "Волконская", nil, false, "female", true},
+		{"turkic patronymic", "Алиев", ptr("Гейдар оглы"), false, "male", true},
+		{"unknown cyrillic", "Черных", nil, false, "", false},
+
+		// Транслитерация с кириллицы
+		{"transliterated patronymic", "Smirnov", ptr("Ivanovich"), true, "male", true},
+		{"transliterated surname", "Petrova", nil, true, "female", true},
+		{"transliterated skaya", "Volkonskaya", nil, true, "female", true},
+
+		// Неславянская латиница: правила не применяются, решает genderize
+		{"casanova", "Casanova", nil, false, "", false},
+		{"villanova", "Villanova", nil, false, "", false},
+		{"ulrich patronymic", "Müller", ptr("Ulrich"), false, "", false},
+		{"heinrich patronymic", "Schmidt", ptr("Heinrich"), false, "", false},
+		{"sky surname", "Kowalsky", nil, false, "", false},
+		{"ov surname", "Tarkov", nil, false, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gender, _, ok := InferGender(tt.surname, tt.patronymic, tt.transliterated)
+			if gender != tt.wantGender || ok != tt.wantOK {
+				t.Errorf("InferGender(%q, %v, %v) = %q, %v; want %q, %v",
+					tt.surname, tt.patronymic, tt.transliterated, gender, ok, tt.wantGender, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTransliteratedName(t *testing.T) {
+	lang := "ru"
+	tests := []struct {
+		name   string
+		person model.Person
+		want   bool
+	}{
+		{"latin without language", model.Person{Name: "Giacomo", Surname: "Casanova"}, false},
+		{"latin with language", model.Person{Name: "Olga", Surname: "Petrova", Language: &lang}, true},
+		{"cyrillic", model.Person{Name: "Ольга", Surname: "Петрова"}, true},
+		{"cyrillic name, latin surname", model.Person{Name: "Ольга", Surname: "Petrova"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transliteratedName(&tt.person); got != tt.want {
+				t.Errorf("transliteratedName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"golang.org/x/text/unicode/norm"
+)
+
+// diminutivesJSON — словарь уменьшительных форм имён, поставляемый с сервисом
+//
+//go:embed diminutives.json
+var diminutivesJSON []byte
+
+// diminutiveEntry — полное имя и его уменьшительные формы
+type diminutiveEntry struct {
+	Canonical   string   `json:"canonical"`
+	Gender      string   `json:"gender"`
+	Diminutives []string `json:"diminutives"`
+}
+
+// diminutives — уменьшительная форма (в нижнем регистре) → возможные полные имена
+var diminutives = loadDiminutives(diminutivesJSON)
+
+func loadDiminutives(data []byte) map[string][]diminutiveEntry {
+	var entries []diminutiveEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		panic("service: invalid diminutives.json: " + err.Error())
+	}
+
+	index := make(map[string][]diminutiveEntry)
+	for _, e := range entries {
+		for _, d := range e.Diminutives {
+			key := strings.ToLower(d)
+			index[key] = append(index[key], e)
+		}
+	}
+	return index
+}
+
+// NormalizeName приводит имя к NFC, убирает лишние пробелы и приводит регистр: «  дМИТРИЙ » → «Дмитрий»,
+// «анна-мария» → «Анна-Мария», «o'neil» → «O'Neil». Слова, которые уже начинаются
+// с заглавной и содержат строчные («McDonald»), остаются как есть.
+func NormalizeName(name string) string {
+	words := strings.Fields(norm.NFC.String(name))
+	for i, word := range words {
+		words[i] = normalizeWord(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func normalizeWord(word string) string {
+	first, _ := utf8.DecodeRuneInString(word)
+	if unicode.IsUpper(first) && word != strings.ToUpper(word) {
+		return word
+	}
+
+	var builder strings.Builder
+	capitalize := true
+	runes := []rune(strings.ToLower(word))
+	for i, r := range runes {
+		if capitalize && unicode.IsLetter(r) {
+			r = unicode.ToUpper(r)
+			capitalize = false
+		}
+		builder.WriteRune(r)
+
+		switch {
+		case r == '-':
+			capitalize = true
+		case isApostrophe(r):
+			// O'Neil, D'Artagnan — но не «п'ятниця», где апостроф внутри слова
+			capitalize = i == 1 && unicode.Is(unicode.Latin, runes[0])
+		}
+	}
+	return builder.String()
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// CanonicalName раскрывает уменьшительную форму имени: «Саша» → «Александр».
+// Если форма соответствует нескольким именам, выбор делается по полу;
+// при неоднозначности возвращается ok=false.
+func CanonicalName(name, gender string) (string, bool) {
+	entries := diminutives[strings.ToLower(strings.TrimSpace(name))]
+
+	var found []diminutiveEntry
+	for _, e := range entries {
+		if gender == "" || e.Gender == gender {
+			found = append(found, e)
+		}
+	}
+	if len(found) != 1 {
+		return "", false
+	}
+	return found[0].Canonical, true
+}
+
+// canonicalCandidates возвращает все полные имена для уменьшительной формы (для поиска)
+func canonicalCandidates(name string) []string {
+	entries := diminutives[strings.ToLower(strings.TrimSpace(name))]
+	result := make([]string, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Canonical)
+	}
+	return result
+}
+
+// normalizePerson нормализует ФИО, сохраняя исходный ввод рядом,
+// и раскрывает уменьшительную форму имени
+func normalizePerson(person *model.Person) {
+	name, surname := person.Name, person.Surname
+	person.NameOriginal = &name
+	person.SurnameOriginal = &surname
+	person.Name = NormalizeName(name)
+	person.Surname = NormalizeName(surname)
+
+	person.PatronymicOriginal = nil
+	if person.Patronymic != nil {
+		original := *person.Patronymic
+		normalized := NormalizeName(original)
+		person.PatronymicOriginal = &original
+		person.Patronymic = &normalized
+	}
+
+	// Пол по отчеству/фамилии помогает выбрать между «Саша» → Александр/Александра
+	gender, _, _ := InferGender(person.Surname, person.Patronymic, transliteratedName(person))
+	canonical := person.Name
+	if c, ok := CanonicalName(person.Name, gender); ok {
+		canonical = c
+	}
+	person.CanonicalName = &canonical
+}
@@ -0,0 +1,43 @@
+package service
+
+import "testing"
+
+func TestCanonicalName(t *testing.T) {
+	tests := []struct {
+		name   string
+		gender string
+		want   string
+		wantOK bool
+	}{
+		{"Ваня", "", "Иван", true},
+		{"ваня", "male", "Иван", true},
+		{"Ваня", "female", "", false},
+
+		// «Саша» — и Александр, и Александра: без пола не раскрывается
+		{"Саша", "", "", false},
+		{"Саша", "male", "Александр", true},
+		{"Саша", "female", "Александра", true},
+		{"Sasha", "", "", false},
+		{"Sasha", "male", "Aleksandr", true},
+		{"Sasha", "female", "Aleksandra", true},
+		{"Shura", "", "", false},
+
+		{"Женя", "", "", false},
+		{"Alex", "female", "Alexandra", true},
+		{"Иван", "", "", false},
+
+		// Самостоятельные имена, а не уменьшительные формы
+		{"Алёна", "female", "", false},
+		{"Аля", "female", "", false},
+		{"Liam", "male", "", false},
+		{"Лена", "female", "Елена", true},
+		{"Bill", "male", "William", true},
+	}
+
+	for _, tt := range tests {
+		got, ok := CanonicalName(tt.name, tt.gender)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CanonicalName(%q, %q) = %q, %v; want %q, %v", tt.name, tt.gender, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
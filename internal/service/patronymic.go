@@ -0,0 +1,130 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// patronymicFathers — отчества, имя отца по которым не выводится общими правилами
+var patronymicFathers = map[string]string{
+	"львович":    "лев",
+	"львовна":    "лев",
+	"павлович":   "павел",
+	"павловна":   "павел",
+	"михайлович": "михаил",
+	"михайловна": "михаил",
+	"яковлевич":  "яков",
+	"яковлевна":  "яков",
+	"ильич":      "илья",
+	"ильинична":  "илья",
+}
+
+// fatherNameRules — окончания отчеств и восстановление имени отца по основе;
+// более длинные окончания проверяются первыми
+var fatherNameRules = []struct {
+	suffix string
+	name   func(stem []rune) string
+}{
+	{"ович", hardStemName},
+	{"овна", hardStemName},
+	{"евич", softStemName},
+	{"евна", softStemName},
+	{"инична", aStemName},
+	{"ична", aStemName},
+	{"ич", aStemName},
+}
+
+// turkicPatronymicMarkers — «оглы»/«кызы» после имени отца
+var turkicPatronymicMarkers = map[string]bool{"оглы": true, "улы": true, "ұлы": true, "кызы": true, "қызы": true}
+
+// Иван-ович → Иван
+func hardStemName(stem []rune) string {
+	return string(stem)
+}
+
+// Серге-евич → Сергей, Игор-евич → Игорь, Юрь-евич → Юрий
+func softStemName(stem []rune) string {
+	last := stem[len(stem)-1]
+	switch {
+	case last == 'ь':
+		return string(stem[:len(stem)-1]) + "ий"
+	case strings.ContainsRune("аеёиоуыэюя", last):
+		return string(stem) + "й"
+	default:
+		return string(stem) + "ь"
+	}
+}
+
+// Никит-ична → Никита, Кузьм-ич → Кузьма
+func aStemName(stem []rune) string {
+	return string(stem) + "а"
+}
+
+// fatherNameFromPatronymic восстанавливает имя отца по отчеству: «Сергеевич» → «Сергей»,
+// «Игоревна» → «Игорь», «Никитична» → «Никита», «Ахмед оглы» → «Ахмед».
+// Поддерживаются только отчества кириллицей.
+func fatherNameFromPatronymic(patronymic string) (string, bool) {
+	words := strings.Fields(strings.ToLower(patronymic))
+	switch {
+	case len(words) == 2 && turkicPatronymicMarkers[words[1]]:
+		return NormalizeName(words[0]), isCyrillicWord(words[0])
+	case len(words) != 1 || !isCyrillicWord(words[0]):
+		return "", false
+	}
+
+	word := words[0]
+	if name, ok := patronymicFathers[word]; ok {
+		return NormalizeName(name), true
+	}
+	for _, rule := range fatherNameRules {
+		if !strings.HasSuffix(word, rule.suffix) {
+			continue
+		}
+		stem := []rune(strings.TrimSuffix(word, rule.suffix))
+		if len(stem) < 2 {
+			return "", false
+		}
+		return NormalizeName(rule.name(stem)), true
+	}
+	return "", false
+}
+
+func isCyrillicWord(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Cyrillic, r) && r != '-' {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// maleSurnameEndings — женские окончания фамилий и соответствующие мужские
+var maleSurnameEndings = [][2]string{
+	{"ская", "ский"},
+	{"цкая", "цкий"},
+	{"ова", "ов"},
+	{"ева", "ев"},
+	{"ина", "ин"},
+	{"ына", "ын"},
+}
+
+// fatherSurnames возвращает возможные фамилии отца в виде для сравнения (foldName):
+// у дочери (женское отчество) фамилия дополнительно приводится к мужской форме
+func fatherSurnames(surname string, patronymic string) []string {
+	folded := foldName(surname)
+	result := []string{folded}
+	if gender, ok := patronymicSuffixes.match(patronymic); !ok || gender != "female" {
+		return result
+	}
+	for _, ending := range maleSurnameEndings {
+		if strings.HasSuffix(folded, ending[0]) && len(folded) > len(ending[0]) {
+			return append(result, strings.TrimSuffix(folded, ending[0])+ending[1])
+		}
+	}
+	return result
+}
+
+// foldName приводит имя к виду для сравнения: нижний регистр, «ё» → «е»
+func foldName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "ё", "е")
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFatherNameFromPatronymic(t *testing.T) {
+	tests := []struct {
+		patronymic string
+		want       string
+		wantOK     bool
+	}{
+		// Общие правила
+		{"Иванович", "Иван", true},
+		{"Ивановна", "Иван", true},
+		{"Сергеевич", "Сергей", true},
+		{"Сергеевна", "Сергей", true},
+		{"Андреевич", "Андрей", true},
+		{"Юрьевич", "Юрий", true},
+		{"Юрьевна", "Юрий", true},
+		{"Игоревич", "Игорь", true},
+		{"Игоревна", "Игорь", true},
+		{"Никитична", "Никита", true},
+		{"Кузьмич", "Кузьма", true},
+
+		// Исключения
+		{"Львович", "Лев", true},
+		{"Львовна", "Лев", true},
+		{"Павлович", "Павел", true},
+		{"Михайловна", "Михаил", true},
+		{"Яковлевич", "Яков", true},
+		{"Ильич", "Илья", true},
+		{"Ильинична", "Илья", true},
+
+		// Регистр и пробелы не важны
+		{"  иванович ", "Иван", true},
+		{"ИВАНОВНА", "Иван", true},
+
+		// Тюркские отчества
+		{"Ахмед оглы", "Ахмед", true},
+		{"Гейдар Оглы", "Гейдар", true},
+		{"Мамед кызы", "Мамед", true},
+		{"Нурлан ұлы", "Нурлан", true},
+		{"Ahmed oglu", "", false},
+
+		// Не выводится
+		{"", "", false},
+		{"Петрович Иванович", "", false},
+		{"Ivanovich", "", false},
+		{"Ович", "", false},
+		{"Мария", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := fatherNameFromPatronymic(tt.patronymic)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("fatherNameFromPatronymic(%q) = %q, %v; want %q, %v", tt.patronymic, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFatherSurnames(t *testing.T) {
+	tests := []struct {
+		surname    string
+		patronymic string
+		want       []string
+	}{
+		// Сын: фамилия как есть
+		{"Петров", "Иванович", []string{"петров"}},
+		{"Волконский", "Сергеевич", []string{"волконский"}},
+
+		// Дочь: дополнительно мужская форма
+		{"Петрова", "Ивановна", []string{"петрова", "петров"}},
+		{"Соловьёва", "Игоревна", []string{"соловьева", "соловьев"}},
+		{"Пушкина", "Сергеевна", []string{"пушкина", "пушкин"}},
+		{"Волконская", "Никитична", []string{"волконская", "волконский"}},
+		{"Троцкая", "Львовна", []string{"троцкая", "троцкий"}},
+		{"Алиева", "Гейдар кызы", []string{"алиева", "алиев"}},
+
+		// Несклоняемые фамилии и неизвестный пол
+		{"Черных", "Ивановна", []string{"черных"}},
+		{"Шевченко", "Петровна", []string{"шевченко"}},
+		{"Петрова", "", []string{"петрова"}},
+		{"Ова", "Ивановна", []string{"ова"}},
+	}
+
+	for _, tt := range tests {
+		if got := fatherSurnames(tt.surname, tt.patronymic); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("fatherSurnames(%q, %q) = %q, want %q", tt.surname, tt.patronymic, got, tt.want)
+		}
+	}
+}
@@ -3,54 +3,114 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/local"
 	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/postgresql"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/phone"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
 )
 
+// Config — настройки сервиса
+type Config struct {
+	// TranslitScheme — схема транслитерации имён для запросов к API (см. translit.Names)
+	TranslitScheme string
+
+	// MixedScriptPolicy — MixedScriptReject (по умолчанию) или MixedScriptFlag
+	MixedScriptPolicy string
+
+	// GenderPrecedence — порядок правил и genderize, по умолчанию GenderRulesFirst
+	GenderPrecedence string
+
+	// EnrichmentSource — откуда брать данные обогащения, по умолчанию EnrichmentAPI
+	EnrichmentSource string
+
+	// Dataset — локальный набор данных для EnrichmentLocal и вариантов с резервом
+	Dataset *local.Dataset
+
+	// PreviewCacheTTL и PreviewCacheSize ограничивают кэш предпросмотра; 0 — без кэша
+	PreviewCacheTTL  time.Duration
+	PreviewCacheSize int
+
+	// PhoneCountryCode — код страны для телефонов без международного префикса,
+	// по умолчанию DefaultPhoneCountryCode
+	PhoneCountryCode string
+}
+
 type PersonService struct {
 	personRepo *postgresql.PersonRepository
 	apiClient  *api.APIClient
+	dataset    *local.Dataset
+	enricher   Enricher
+	scheme     *translit.Scheme
+
+	previewCache *previewCache
+
+	mixedScriptPolicy string
+	genderPrecedence  string
+	phoneCountryCode  string
 }
 
-func NewPersonService(personRepo *postgresql.PersonRepository, apiClient *api.APIClient) *PersonService {
-	return &PersonService{
-		personRepo: personRepo,
-		apiClient:  apiClient,
+func NewPersonService(personRepo *postgresql.PersonRepository, apiClient *api.APIClient, cfg Config) (*PersonService, error) {
+	schemeName := cfg.TranslitScheme
+	if schemeName == "" {
+		schemeName = translit.DefaultScheme
+	}
+	scheme, err := translit.Lookup(schemeName)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// Create создаёт человека и обогащает данные через внешние API
-func (s *PersonService) Create(ctx context.Context, input model.PersonInput) (*model.Person, error) {
-	// 1. Подготовка базовой структуры
-	person := &model.Person{
-		Name:       input.Name,
-		Surname:    input.Surname,
-		Patronymic: input.Patronymic,
+	switch cfg.MixedScriptPolicy {
+	case "":
+		cfg.MixedScriptPolicy = MixedScriptReject
+	case MixedScriptReject, MixedScriptFlag:
+	default:
+		return nil, fmt.Errorf("unknown mixed script policy %q", cfg.MixedScriptPolicy)
 	}
 
-	//транслитерация для кириллицы
-	nameForAPI := Transliterate(input.Name)
+	switch cfg.GenderPrecedence {
+	case "":
+		cfg.GenderPrecedence = GenderRulesFirst
+	case GenderRulesFirst, GenderAPIFirst, GenderRulesOnly, GenderAPIOnly:
+	default:
+		return nil, fmt.Errorf("unknown gender precedence %q", cfg.GenderPrecedence)
+	}
 
-	// 2. Обогащение данных (параллельные запросы к API)
-	age, err := s.apiClient.GetAge(ctx, nameForAPI)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get age: %w", err)
+	if cfg.PhoneCountryCode == "" {
+		cfg.PhoneCountryCode = DefaultPhoneCountryCode
+	}
+	if !phone.ValidCountryCode(cfg.PhoneCountryCode) {
+		return nil, fmt.Errorf("invalid phone country code %q", cfg.PhoneCountryCode)
 	}
-	person.Age = &age
 
-	gender, err := s.apiClient.GetGender(ctx, person.Name)
+	enricher, err := newEnricher(cfg.EnrichmentSource, apiClient, cfg.Dataset)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gender: %w", err)
+		return nil, err
 	}
-	person.Gender = &gender
 
-	nationality, err := s.apiClient.GetNationality(ctx, person.Name)
+	return &PersonService{
+		personRepo:        personRepo,
+		apiClient:         apiClient,
+		dataset:           cfg.Dataset,
+		enricher:          enricher,
+		scheme:            scheme,
+		mixedScriptPolicy: cfg.MixedScriptPolicy,
+		genderPrecedence:  cfg.GenderPrecedence,
+		phoneCountryCode:  cfg.PhoneCountryCode,
+		previewCache:      newPreviewCache(cfg.PreviewCacheTTL, cfg.PreviewCacheSize),
+	}, nil
+}
+
+// Create создаёт человека и обогащает данные через внешние API или локальный набор данных
+func (s *PersonService) Create(ctx context.Context, input model.PersonInput) (*model.Person, error) {
+	// 1-2. Нормализация ФИО и обогащение данных
+	person, err := s.buildPerson(ctx, s.enricher, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nationality: %w", err)
+		return nil, err
 	}
-	person.Nationality = &nationality
 
 	// 3. Сохранение в БД
 	id, err := s.personRepo.Create(ctx, person)
@@ -62,13 +122,205 @@ func (s *PersonService) Create(ctx context.Context, input model.PersonInput) (*m
 	return person, nil
 }
 
-// GetByID возвращает человека по ID
-func (s *PersonService) GetByID(ctx context.Context, id int64) (*model.Person, error) {
+// buildPerson нормализует ФИО и обогащает его через e, ничего не сохраняя
+func (s *PersonService) buildPerson(ctx context.Context, e Enricher, input model.PersonInput) (*model.Person, error) {
+	// Подготовка базовой структуры: нормализованное ФИО рядом с исходным вводом
+	person := &model.Person{
+		Name:       input.Name,
+		Surname:    input.Surname,
+		Patronymic: input.Patronymic,
+		BirthDate:  input.BirthDate,
+		BirthYear:  input.BirthYear,
+		Language:   input.Language,
+		Attributes: input.Attributes,
+	}
+	normalizePerson(person)
+
+	// Атрибуты и теги проверяются до обогащения, чтобы не расходовать квоту API
+	if err := s.validateAttributes(ctx, person.Attributes); err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	person.Tags = tags
+
+	// Возраст по дате рождения введён человеком: agify для него не нужен
+	now := time.Now().UTC()
+	if err := applyBirthDate(person, now); err != nil {
+		return nil, err
+	}
+	if hasBirthDate(person) {
+		setProvenance(person, FieldAge, ProvenanceManual, now)
+	}
+
+	script, err := s.personScript(person.Name, person.Surname, person.Patronymic)
+	if err != nil {
+		return nil, err
+	}
+	person.Script = &script
+
+	// Имя в том виде, который ожидает каждый провайдер (транслитерация для кириллицы);
+	// для обогащения используется полная форма имени
+	queries := s.enrichmentQueries(model.PersonInput{
+		Name:       *person.CanonicalName,
+		Surname:    person.Surname,
+		Patronymic: person.Patronymic,
+		Language:   person.Language,
+	})
+	person.EnrichmentQueries = queries
+
+	// Обогащение данных (параллельные запросы к API)
+	if err := s.enrich(ctx, e, person, queries, false); err != nil {
+		return nil, err
+	}
+	enrichedAt := time.Now().UTC()
+	person.EnrichedAt = &enrichedAt
+
+	return person, nil
+}
+
+// enrich заполняет возраст, пол и национальность из источника обогащения e и
+// записывает их происхождение. Поля, введённые вручную, пропускаются, если не
+// задан force; запросы по ним удаляются из queries.
+func (s *PersonService) enrich(ctx context.Context, e Enricher, person *model.Person, queries map[string]string, force bool) error {
+	now := time.Now().UTC()
+
+	if force || !isLocked(person, FieldAge) {
+		age, err := e.GetAge(ctx, queries[api.ProviderAgify])
+		if err != nil {
+			return fmt.Errorf("failed to get age: %w", err)
+		}
+		person.EstimatedAge = &age.Age
+		person.EstimatedAgeAt = &now
+		person.AgeCount = &age.Count
+		// Возраст по дате рождения точнее оценки, оценка лишь сохраняется отдельно
+		if !hasBirthDate(person) {
+			person.Age = &age.Age
+			setProvenance(person, FieldAge, age.Source, now)
+		}
+	} else {
+		delete(queries, api.ProviderAgify)
+	}
+
+	if force || !isLocked(person, FieldGender) {
+		if err := s.resolveGender(ctx, e, person, queries, now); err != nil {
+			return err
+		}
+	} else {
+		delete(queries, api.ProviderGenderize)
+	}
+
+	if force || !isLocked(person, FieldNationality) {
+		nationality, err := e.GetNationality(ctx, queries[api.ProviderNationalize])
+		if err != nil {
+			return fmt.Errorf("failed to get nationality: %w", err)
+		}
+		person.Nationality = nil
+		person.NationalityProbability = nil
+		if nationality.CountryID != "" {
+			person.Nationality = &nationality.CountryID
+			person.NationalityProbability = &nationality.Probability
+		}
+		person.Nationalities = make([]model.NationalityCandidate, len(nationality.Candidates))
+		for i, c := range nationality.Candidates {
+			person.Nationalities[i] = model.NationalityCandidate{
+				CountryID:   c.CountryID,
+				Probability: c.Probability,
+				Rank:        i + 1,
+			}
+		}
+		setProvenance(person, FieldNationality, nationality.Source, now)
+	} else {
+		delete(queries, api.ProviderNationalize)
+	}
+
+	return nil
+}
+
+// resolveGender определяет пол по правилам и/или через genderize согласно
+// genderPrecedence и записывает источник значения
+func (s *PersonService) resolveGender(ctx context.Context, e Enricher, person *model.Person, queries map[string]string, now time.Time) error {
+	applyRules := func() bool {
+		gender, probability, ok := InferGender(person.Surname, person.Patronymic, transliteratedName(person))
+		if !ok {
+			return false
+		}
+		source := GenderSourceRules
+		person.Gender = &gender
+		person.GenderProbability = &probability
+		person.GenderCount = nil
+		person.GenderSource = &source
+		setProvenance(person, FieldGender, ProvenanceRule, now)
+		return true
+	}
+
+	applyAPI := func() (bool, error) {
+		gender, err := e.GetGender(ctx, queries[api.ProviderGenderize])
+		if err != nil {
+			return false, fmt.Errorf("failed to get gender: %w", err)
+		}
+		if gender.Gender == "" {
+			return false, nil
+		}
+		source := GenderSourceAPI
+		person.Gender = &gender.Gender
+		person.GenderProbability = &gender.Probability
+		person.GenderCount = &gender.Count
+		person.GenderSource = &source
+		setProvenance(person, FieldGender, gender.Source, now)
+		return true, nil
+	}
+
+	calledAPI := false
+	var err error
+	switch s.genderPrecedence {
+	case GenderRulesOnly:
+		applyRules()
+	case GenderAPIOnly:
+		calledAPI = true
+		_, err = applyAPI()
+	case GenderAPIFirst:
+		calledAPI = true
+		var ok bool
+		if ok, err = applyAPI(); err == nil && !ok {
+			applyRules()
+		}
+	default: // GenderRulesFirst
+		if !applyRules() {
+			calledAPI = true
+			_, err = applyAPI()
+		}
+	}
+
+	// В запросах для отладки оставляем только то, что действительно отправили
+	if !calledAPI {
+		delete(queries, api.ProviderGenderize)
+	}
+	return err
+}
+
+// Quotas возвращает состояние квот внешних API
+func (s *PersonService) Quotas() []api.QuotaState {
+	if s.apiClient == nil {
+		return []api.QuotaState{}
+	}
+	return s.apiClient.Quotas()
+}
+
+// GetByID возвращает человека по ID; с includeDeleted — и мягко удалённого
+func (s *PersonService) GetByID(ctx context.Context, id int64, includeDeleted bool) (*model.Person, error) {
+	if includeDeleted {
+		return s.personRepo.GetByIDIncludingDeleted(ctx, id)
+	}
 	return s.personRepo.GetByID(ctx, id)
 }
 
 // GetAll возвращает список людей с пагинацией
 func (s *PersonService) GetAll(ctx context.Context, filterParams model.FilterParams) ([]model.Person, error) {
+	s.prepareFilter(&filterParams)
+
 	// Передаем фильтры в репозиторий
 	people, err := s.personRepo.GetAll(ctx, filterParams)
 	if err != nil {
@@ -77,12 +329,80 @@ func (s *PersonService) GetAll(ctx context.Context, filterParams model.FilterPar
 	return people, nil
 }
 
-// Update обновляет данные человека
+// prepareFilter дополняет фильтры списка значениями, которые вычисляет сервис
+func (s *PersonService) prepareFilter(filterParams *model.FilterParams) {
+	// Поиск по «Саша» находит и тех, у кого полное имя Александр/Александра
+	if filterParams.Name != nil {
+		filterParams.CanonicalNames = canonicalCandidates(*filterParams.Name)
+	}
+	cleanTagFilters(filterParams)
+}
+
+// Update обновляет данные человека. Изменённые возраст, пол и национальность
+// помечаются как введённые вручную и блокируются от автоматического обогащения.
 func (s *PersonService) Update(ctx context.Context, id int64, person *model.Person) error {
+	current, err := s.personRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	applyManualChanges(current, person, now)
+	if err := applyBirthDate(person, now); err != nil {
+		return err
+	}
+
+	// Язык и атрибуты меняются, только если переданы; атрибуты при этом проверяются
+	if person.Language == nil {
+		person.Language = current.Language
+	}
+	if person.Attributes == nil {
+		person.Attributes = current.Attributes
+	} else if err := s.validateAttributes(ctx, person.Attributes); err != nil {
+		return err
+	}
+	if person.Tags != nil {
+		if person.Tags, err = normalizeTags(person.Tags); err != nil {
+			return err
+		}
+	}
+
+	normalizePerson(person)
+
+	script, err := s.personScript(person.Name, person.Surname, person.Patronymic)
+	if err != nil {
+		return err
+	}
+	person.Script = &script
+
 	return s.personRepo.Update(ctx, id, person)
 }
 
-// Delete удаляет человека по ID
+// Delete мягко удаляет человека по ID
 func (s *PersonService) Delete(ctx context.Context, id int64) error {
 	return s.personRepo.Delete(ctx, id)
 }
+
+// Restore восстанавливает мягко удалённого человека
+func (s *PersonService) Restore(ctx context.Context, id int64) (*model.Person, error) {
+	if err := s.personRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.personRepo.GetByID(ctx, id)
+}
+
+// History возвращает журнал изменений человека, в том числе удалённого
+func (s *PersonService) History(ctx context.Context, id int64) ([]model.PersonHistoryEntry, error) {
+	history, err := s.personRepo.History(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, postgresql.ErrPersonNotFound
+	}
+	return history, nil
+}
+
+// GetAsOf восстанавливает данные человека на момент at
+func (s *PersonService) GetAsOf(ctx context.Context, id int64, at time.Time) (*model.Person, error) {
+	return s.personRepo.GetAsOf(ctx, id, at)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/api"
+)
+
+// Preview прогоняет ввод через тот же конвейер, что и Create, но ничего не
+// сохраняет. Результаты кэшируются, чтобы повторный просмотр не расходовал квоту.
+func (s *PersonService) Preview(ctx context.Context, input model.PersonInput) (*model.EnrichmentPreview, error) {
+	// Теги проверяются до обращения к кэшу: неверные не должны проходить за счёт
+	// закэшированного результата, а одинаковые в разной записи — давать разные ключи
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	input.Tags = tags
+
+	key := previewKey(ctx, input)
+	if preview, ok := s.previewCache.get(key, time.Now()); ok {
+		return preview, nil
+	}
+
+	timed := &timingEnricher{Enricher: s.enricher}
+	person, err := s.buildPerson(ctx, timed, input)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &model.EnrichmentPreview{Person: person, Timings: timed.result()}
+	s.previewCache.put(key, preview, time.Now())
+	return preview, nil
+}
+
+// previewKey — ключ кэша: ввод как есть, включая язык, и арендатор, по схеме
+// которого проверяются атрибуты. Теги должны быть уже нормализованы.
+func previewKey(ctx context.Context, input model.PersonInput) string {
+	parts := []string{auth.Tenant(ctx), input.Name, input.Surname, "", "", "", "", "", ""}
+	if input.Patronymic != nil {
+		parts[3] = *input.Patronymic
+	}
+	if input.Language != nil {
+		parts[4] = *input.Language
+	}
+	if input.BirthDate != nil {
+		parts[5] = *input.BirthDate
+	}
+	if input.BirthYear != nil {
+		parts[6] = strconv.Itoa(*input.BirthYear)
+	}
+	if input.Attributes != nil {
+		// encoding/json сортирует ключи map, так что одинаковые атрибуты дают одну строку
+		encoded, _ := json.Marshal(input.Attributes)
+		parts[7] = string(encoded)
+	}
+	parts[8] = strings.Join(input.Tags, ",")
+	return strings.Join(parts, "\x00")
+}
+
+// timingEnricher замеряет время запросов к источнику обогащения
+type timingEnricher struct {
+	Enricher
+
+	mu      sync.Mutex
+	timings []model.ProviderTiming
+}
+
+func (t *timingEnricher) GetAge(ctx context.Context, name string) (api.AgeResult, error) {
+	start := time.Now()
+	result, err := t.Enricher.GetAge(ctx, name)
+	t.record(api.ProviderAgify, result.Source, start, err)
+	return result, err
+}
+
+func (t *timingEnricher) GetGender(ctx context.Context, name string) (api.GenderResult, error) {
+	start := time.Now()
+	result, err := t.Enricher.GetGender(ctx, name)
+	t.record(api.ProviderGenderize, result.Source, start, err)
+	return result, err
+}
+
+func (t *timingEnricher) GetNationality(ctx context.Context, name string) (api.NationalityResult, error) {
+	start := time.Now()
+	result, err := t.Enricher.GetNationality(ctx, name)
+	t.record(api.ProviderNationalize, result.Source, start, err)
+	return result, err
+}
+
+func (t *timingEnricher) record(provider, source string, start time.Time, err error) {
+	timing := model.ProviderTiming{
+		Provider:   provider,
+		Source:     source,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		timing.Error = err.Error()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timings = append(t.timings, timing)
+}
+
+func (t *timingEnricher) result() []model.ProviderTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]model.ProviderTiming{}, t.timings...)
+}
+
+// previewCache — кэш результатов предпросмотра с ограничением по времени и размеру.
+// nil-кэш ничего не хранит.
+type previewCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]previewEntry
+}
+
+type previewEntry struct {
+	preview   *model.EnrichmentPreview
+	expiresAt time.Time
+}
+
+// newPreviewCache создаёт кэш; при ttl <= 0 или size <= 0 кэширование отключено (nil)
+func newPreviewCache(ttl time.Duration, size int) *previewCache {
+	if ttl <= 0 || size <= 0 {
+		return nil
+	}
+	return &previewCache{ttl: ttl, size: size, entries: make(map[string]previewEntry)}
+}
+
+func (c *previewCache) get(key string, now time.Time) (*model.EnrichmentPreview, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expiresAt) {
+		return nil, false
+	}
+	cached := *entry.preview
+	cached.Cached = true
+	return &cached, true
+}
+
+func (c *previewCache) put(key string, preview *model.EnrichmentPreview, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	c.entries[key] = previewEntry{preview: preview, expiresAt: now.Add(c.ttl)}
+}
+
+// evict удаляет просроченные записи, а если их нет — запись, которая истекает раньше всех
+func (c *previewCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, entry.expiresAt
+		}
+	}
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldestKey)
+	}
+}
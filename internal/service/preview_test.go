@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+func TestPreviewKeyAttributes(t *testing.T) {
+	ctx := context.Background()
+	base := model.PersonInput{Name: "Иван", Surname: "Петров"}
+
+	withAttrs := func(attrs map[string]interface{}) model.PersonInput {
+		input := base
+		input.Attributes = attrs
+		return input
+	}
+
+	sales := previewKey(ctx, withAttrs(map[string]interface{}{"department": "sales", "level": 2}))
+	support := previewKey(ctx, withAttrs(map[string]interface{}{"department": "support", "level": 2}))
+	if sales == support {
+		t.Fatal("previews with different attributes share a cache key")
+	}
+	if none := previewKey(ctx, base); none == sales {
+		t.Fatal("preview without attributes shares a cache key with one that has them")
+	}
+	if again := previewKey(ctx, withAttrs(map[string]interface{}{"level": 2, "department": "sales"})); again != sales {
+		t.Fatal("equal attributes produce different cache keys")
+	}
+
+	cache := newPreviewCache(time.Minute, 10)
+	now := time.Now()
+	cache.put(sales, &model.EnrichmentPreview{Person: &model.Person{Name: "Иван"}}, now)
+	if _, ok := cache.get(support, now); ok {
+		t.Fatal("preview with different attributes was served from cache")
+	}
+	if _, ok := cache.get(sales, now); !ok {
+		t.Fatal("identical preview was not served from cache")
+	}
+}
+
+func TestPreviewKeyTenant(t *testing.T) {
+	input := model.PersonInput{Name: "Иван", Surname: "Петров"}
+	acme := auth.WithPrincipal(context.Background(), auth.Principal{Tenant: "acme"})
+	globex := auth.WithPrincipal(context.Background(), auth.Principal{Tenant: "globex"})
+
+	if previewKey(acme, input) == previewKey(globex, input) {
+		t.Fatal("previews of different tenants share a cache key")
+	}
+}
+
+func TestPreviewKeyTags(t *testing.T) {
+	ctx := context.Background()
+	vip := model.PersonInput{Name: "Иван", Surname: "Петров", Tags: []string{"vip"}}
+	review := model.PersonInput{Name: "Иван", Surname: "Петров", Tags: []string{"needs-review"}}
+
+	if previewKey(ctx, vip) == previewKey(ctx, review) {
+		t.Fatal("previews with different tags share a cache key")
+	}
+}
+
+func TestPreviewRejectsInvalidTagsBeforeCache(t *testing.T) {
+	s := &PersonService{previewCache: newPreviewCache(time.Minute, 10)}
+	input := model.PersonInput{Name: "Иван", Surname: "Петров", Tags: []string{"bad tag!"}}
+
+	// Даже если такой ввод уже есть в кэше, неверный тег отклоняется
+	s.previewCache.put(previewKey(context.Background(), input), &model.EnrichmentPreview{}, time.Now())
+
+	if _, err := s.Preview(context.Background(), input); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("Preview() error = %v, want %v", err, ErrInvalidTag)
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/repository/local"
+)
+
+// Обогащаемые поля, для которых хранится происхождение
+const (
+	FieldAge         = "age"
+	FieldGender      = "gender"
+	FieldNationality = "nationality"
+)
+
+// Источники значений, кроме api:<провайдер> (см. api.Source)
+const (
+	ProvenanceRule   = "rule"       // правила по отчеству и фамилии
+	ProvenanceManual = "manual"     // введено оператором
+	ProvenanceImport = local.Source // локальный набор данных
+)
+
+// setProvenance записывает источник и время установки значения поля
+func setProvenance(person *model.Person, field, source string, now time.Time) {
+	if person.Provenance == nil {
+		person.Provenance = make(map[string]model.FieldProvenance)
+	}
+	person.Provenance[field] = model.FieldProvenance{Source: source, UpdatedAt: now}
+}
+
+// isLocked сообщает, что значение поля введено вручную и не должно
+// перезаписываться автоматическим обогащением
+func isLocked(person *model.Person, field string) bool {
+	return person.Provenance[field].Source == ProvenanceManual
+}
+
+// applyManualChanges помечает как ручные поля, значения которых отличаются от
+// сохранённых. Оценки провайдера для таких полей теряют смысл и сбрасываются;
+// для остальных полей сохраняются прежние значения и происхождение.
+func applyManualChanges(current, updated *model.Person, now time.Time) {
+	updated.Provenance = current.Provenance
+	updated.EstimatedAge = current.EstimatedAge
+	updated.EstimatedAgeAt = current.EstimatedAgeAt
+
+	// Дата рождения, введённая оператором, делает возраст ручным значением
+	birthChanged := !equalPtr(current.BirthDate, updated.BirthDate) || !equalPtr(current.BirthYear, updated.BirthYear)
+	switch {
+	case birthChanged && hasBirthDate(updated):
+		updated.AgeCount = current.AgeCount
+		setProvenance(updated, FieldAge, ProvenanceManual, now)
+	case equalPtr(current.Age, updated.Age):
+		updated.AgeCount = current.AgeCount
+	default:
+		updated.AgeCount = nil
+		setProvenance(updated, FieldAge, ProvenanceManual, now)
+	}
+
+	if equalPtr(current.Gender, updated.Gender) {
+		updated.GenderProbability = current.GenderProbability
+		updated.GenderCount = current.GenderCount
+		updated.GenderSource = current.GenderSource
+	} else {
+		source := GenderSourceManual
+		updated.GenderProbability = nil
+		updated.GenderCount = nil
+		updated.GenderSource = &source
+		setProvenance(updated, FieldGender, ProvenanceManual, now)
+	}
+
+	if equalPtr(current.Nationality, updated.Nationality) {
+		updated.NationalityProbability = current.NationalityProbability
+	} else {
+		updated.NationalityProbability = nil
+		setProvenance(updated, FieldNationality, ProvenanceManual, now)
+	}
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/auth"
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/logger"
+)
+
+// PurgeActor — автор окончательного удаления в журнале изменений
+const PurgeActor = "system:purge"
+
+// Purge окончательно удаляет записи, мягко удалённые раньше чем retention назад
+func (s *PersonService) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.personRepo.Purge(ctx, time.Now().Add(-retention))
+}
+
+// PurgeConfig — настройки окончательного удаления
+type PurgeConfig struct {
+	Retention time.Duration // сколько хранить мягко удалённые записи
+	Interval  time.Duration // как часто проверять
+}
+
+// PurgeScheduler периодически удаляет записи с истёкшим сроком хранения
+type PurgeScheduler struct {
+	service *PersonService
+	logger  logger.Logger
+	cfg     PurgeConfig
+}
+
+func NewPurgeScheduler(service *PersonService, logger logger.Logger, cfg PurgeConfig) *PurgeScheduler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	return &PurgeScheduler{service: service, logger: logger, cfg: cfg}
+}
+
+// Run удаляет записи при каждом срабатывании до отмены ctx
+func (p *PurgeScheduler) Run(ctx context.Context) {
+	p.logger.Info("Purge scheduler started", "retention", p.cfg.Retention.String(), "interval", p.cfg.Interval.String())
+
+	ctx = auth.WithPrincipal(ctx, auth.Principal{Subject: PurgeActor})
+
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Purge scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := p.service.Purge(ctx, p.cfg.Retention)
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Error("Purge failed", err)
+			}
+			continue
+		}
+		if purged > 0 {
+			p.logger.Info("Purged deleted people", "count", purged)
+		}
+	}
+}
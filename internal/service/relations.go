@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+// ErrInvalidRelation возвращается для связи человека с самим собой, неизвестной роли
+// или неверных параметров обхода графа
+var ErrInvalidRelation = errors.New("invalid relation")
+
+// Глубина обхода графа связей
+const (
+	DefaultRelationDepth = 2
+	MaxRelationDepth     = 5
+)
+
+// maxGraphNodes ограничивает число людей в графе связей
+const maxGraphNodes = 1000
+
+// Подбор отца по отчеству
+const (
+	maxSuggestions     = 20
+	minParentAgeGap    = 14 // меньшая разница в возрасте исключает кандидата
+	maxParentAgeGap    = 70
+	usualParentAgeFrom = 18
+	usualParentAgeTo   = 50
+)
+
+// relationTypes — все типы связей, по умолчанию для обхода графа
+var relationTypes = []string{model.RelationParent, model.RelationSpouse, model.RelationSibling, model.RelationManager}
+
+// relationEdge переводит роль человека relatedID относительно personID в хранимую связь
+func relationEdge(personID, relatedID int64, role string) (*model.RelationEdge, error) {
+	switch role {
+	case model.RelationParent:
+		return &model.RelationEdge{From: relatedID, To: personID, Type: model.RelationParent}, nil
+	case model.RoleChild:
+		return &model.RelationEdge{From: personID, To: relatedID, Type: model.RelationParent}, nil
+	case model.RelationManager:
+		return &model.RelationEdge{From: relatedID, To: personID, Type: model.RelationManager}, nil
+	case model.RoleReport:
+		return &model.RelationEdge{From: personID, To: relatedID, Type: model.RelationManager}, nil
+	case model.RelationSpouse, model.RelationSibling:
+		// Симметричные связи хранятся один раз, от меньшего ID к большему
+		from, to := min(personID, relatedID), max(personID, relatedID)
+		return &model.RelationEdge{From: from, To: to, Type: role}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidRelation, role)
+}
+
+// ListRelations возвращает связи человека; связи с удалёнными людьми скрыты
+func (s *PersonService) ListRelations(ctx context.Context, personID int64) ([]model.Relation, error) {
+	if _, err := s.personRepo.GetByID(ctx, personID); err != nil {
+		return nil, err
+	}
+	return s.personRepo.ListRelations(ctx, personID)
+}
+
+// AddRelation связывает человека с input.RelatedID в роли input.Role
+func (s *PersonService) AddRelation(ctx context.Context, personID int64, input model.RelationInput) (*model.Relation, error) {
+	if input.RelatedID == personID {
+		return nil, fmt.Errorf("%w: person cannot be related to themselves", ErrInvalidRelation)
+	}
+	edge, err := relationEdge(personID, input.RelatedID, input.Role)
+	if err != nil {
+		return nil, err
+	}
+	return s.personRepo.CreateRelation(ctx, personID, edge)
+}
+
+// DeleteRelation удаляет связь человека
+func (s *PersonService) DeleteRelation(ctx context.Context, personID, id int64) error {
+	if _, err := s.personRepo.GetByID(ctx, personID); err != nil {
+		return err
+	}
+	return s.personRepo.DeleteRelation(ctx, personID, id)
+}
+
+// RelationGraph обходит связи типов types (по умолчанию — все) в ширину, начиная
+// с человека personID, и возвращает людей не дальше depth связей от него
+func (s *PersonService) RelationGraph(ctx context.Context, personID int64, depth int, types []string) (*model.RelationGraph, error) {
+	if depth < 1 || depth > MaxRelationDepth {
+		return nil, fmt.Errorf("%w: depth must be between 1 and %d", ErrInvalidRelation, MaxRelationDepth)
+	}
+	if len(types) == 0 {
+		types = relationTypes
+	}
+	for _, t := range types {
+		switch t {
+		case model.RelationParent, model.RelationSpouse, model.RelationSibling, model.RelationManager:
+		default:
+			return nil, fmt.Errorf("%w: unknown relation type %q", ErrInvalidRelation, t)
+		}
+	}
+	if _, err := s.personRepo.GetByID(ctx, personID); err != nil {
+		return nil, err
+	}
+
+	graph := &model.RelationGraph{RootID: personID, Depth: depth}
+	distance := map[int64]int{personID: 0}
+	edges := make(map[int64]model.RelationEdge)
+	frontier := []int64{personID}
+
+	// Связи людей на последнем уровне запрашиваются ещё раз, но новые люди
+	// уже не добавляются — так в граф попадают связи между ними
+	for level := 1; level <= depth+1 && len(frontier) > 0; level++ {
+		found, err := s.personRepo.RelationEdges(ctx, frontier, types)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int64
+		for _, edge := range found {
+			for _, id := range []int64{edge.From, edge.To} {
+				if _, seen := distance[id]; seen || level > depth {
+					continue
+				}
+				if len(distance) >= maxGraphNodes {
+					graph.Truncated = true
+					continue
+				}
+				distance[id] = level
+				next = append(next, id)
+			}
+
+			_, fromSeen := distance[edge.From]
+			_, toSeen := distance[edge.To]
+			if fromSeen && toSeen {
+				edges[edge.ID] = edge
+			}
+		}
+		frontier = next
+	}
+
+	ids := make([]int64, 0, len(distance))
+	for id := range distance {
+		ids = append(ids, id)
+	}
+	summaries, err := s.personRepo.PersonSummaries(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	graph.Nodes = make([]model.RelationGraphNode, 0, len(ids))
+	for _, id := range ids {
+		graph.Nodes = append(graph.Nodes, model.RelationGraphNode{PersonSummary: summaries[id], Distance: distance[id]})
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		if graph.Nodes[i].Distance != graph.Nodes[j].Distance {
+			return graph.Nodes[i].Distance < graph.Nodes[j].Distance
+		}
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+
+	graph.Edges = make([]model.RelationEdge, 0, len(edges))
+	for _, edge := range edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool { return graph.Edges[i].ID < graph.Edges[j].ID })
+
+	return graph, nil
+}
+
+// RelationSuggestions предлагает вероятных отцов человека: мужчин с именем, выведенным
+// из отчества, той же фамилией (для дочерей — в мужской форме) и правдоподобной
+// разницей в возрасте. Уже связанные родители и дети не предлагаются.
+func (s *PersonService) RelationSuggestions(ctx context.Context, personID int64) ([]model.RelationSuggestion, error) {
+	person, err := s.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []model.RelationSuggestion{}
+	if person.Patronymic == nil {
+		return suggestions, nil
+	}
+	fatherName, ok := fatherNameFromPatronymic(*person.Patronymic)
+	if !ok {
+		return suggestions, nil
+	}
+
+	relations, err := s.personRepo.ListRelations(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	linked := make(map[int64]bool)
+	parents := 0
+	for _, rel := range relations {
+		switch rel.Role {
+		case model.RelationParent:
+			parents++
+			if rel.Related.Gender != nil && *rel.Related.Gender == "male" {
+				// Отец уже указан
+				return suggestions, nil
+			}
+			linked[rel.Related.ID] = true
+		case model.RoleChild:
+			linked[rel.Related.ID] = true
+		}
+	}
+	if parents >= 2 {
+		return suggestions, nil
+	}
+
+	candidates, err := s.personRepo.FatherCandidates(ctx, personID,
+		[]string{foldName(fatherName)}, fatherSurnames(person.Surname, *person.Patronymic), maxSuggestions*5)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if linked[candidate.ID] {
+			continue
+		}
+		suggestion, ok := fatherSuggestion(person, &candidate, fatherName)
+		if ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions, nil
+}
+
+// fatherSuggestion оценивает кандидата в отцы; ok = false, если разница в возрасте
+// делает родство невозможным
+func fatherSuggestion(person, candidate *model.Person, fatherName string) (model.RelationSuggestion, bool) {
+	score := 0.6
+	var reasons []string
+	if foldName(candidate.Name) == foldName(fatherName) {
+		reasons = append(reasons, fmt.Sprintf("patronymic %s matches name %s", *person.Patronymic, candidate.Name))
+	} else {
+		score -= 0.1
+		reasons = append(reasons, fmt.Sprintf("patronymic %s matches full name %s of %s",
+			*person.Patronymic, fatherName, candidate.Name))
+	}
+	reasons = append(reasons, fmt.Sprintf("surname %s matches %s", candidate.Surname, person.Surname))
+
+	if candidate.Gender != nil && *candidate.Gender == "male" {
+		score += 0.1
+		reasons = append(reasons, "gender male")
+	}
+
+	if person.Age != nil && candidate.Age != nil {
+		gap := *candidate.Age - *person.Age
+		switch {
+		case gap < minParentAgeGap || gap > maxParentAgeGap:
+			return model.RelationSuggestion{}, false
+		case gap >= usualParentAgeFrom && gap <= usualParentAgeTo:
+			score += 0.25
+			reasons = append(reasons, fmt.Sprintf("age gap %d years", gap))
+		default:
+			score += 0.05
+			reasons = append(reasons, fmt.Sprintf("age gap %d years is unusual", gap))
+		}
+	} else {
+		reasons = append(reasons, "age unknown")
+	}
+
+	return model.RelationSuggestion{
+		Role: model.RelationParent,
+		Person: model.PersonSummary{
+			ID:         candidate.ID,
+			Name:       candidate.Name,
+			Surname:    candidate.Surname,
+			Patronymic: candidate.Patronymic,
+			Gender:     candidate.Gender,
+			Age:        candidate.Age,
+		},
+		Score:   math.Round(score*100) / 100,
+		Reasons: reasons,
+	}, true
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+func TestRelationEdge(t *testing.T) {
+	tests := []struct {
+		role    string
+		want    model.RelationEdge
+		wantErr bool
+	}{
+		// Человек 2 относительно человека 5
+		{model.RelationParent, model.RelationEdge{From: 2, To: 5, Type: model.RelationParent}, false},
+		{model.RoleChild, model.RelationEdge{From: 5, To: 2, Type: model.RelationParent}, false},
+		{model.RelationManager, model.RelationEdge{From: 2, To: 5, Type: model.RelationManager}, false},
+		{model.RoleReport, model.RelationEdge{From: 5, To: 2, Type: model.RelationManager}, false},
+		{model.RelationSpouse, model.RelationEdge{From: 2, To: 5, Type: model.RelationSpouse}, false},
+		{model.RelationSibling, model.RelationEdge{From: 2, To: 5, Type: model.RelationSibling}, false},
+		{"cousin", model.RelationEdge{}, true},
+		{"", model.RelationEdge{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			got, err := relationEdge(5, 2, tt.role)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRelation) {
+					t.Errorf("relationEdge(5, 2, %q) error = %v, want %v", tt.role, err, ErrInvalidRelation)
+				}
+				return
+			}
+			if err != nil || *got != tt.want {
+				t.Errorf("relationEdge(5, 2, %q) = %+v, %v; want %+v", tt.role, got, err, tt.want)
+			}
+		})
+	}
+
+	// Симметричные связи не зависят от того, с чьей стороны добавлены
+	a, _ := relationEdge(2, 5, model.RelationSpouse)
+	b, _ := relationEdge(5, 2, model.RelationSpouse)
+	if *a != *b {
+		t.Errorf("spouse edges differ by side: %+v vs %+v", a, b)
+	}
+}
+
+func TestFatherSuggestion(t *testing.T) {
+	str := func(s string) *string { return &s }
+	age := func(n int) *int { return &n }
+
+	tests := []struct {
+		name      string
+		person    model.Person
+		candidate model.Person
+		father    string
+		wantScore float64
+		wantOK    bool
+	}{
+		{
+			"male with usual age gap",
+			model.Person{Surname: "Петрова", Patronymic: str("Игоревна"), Age: age(20)},
+			model.Person{Name: "Игорь", Surname: "Петров", Gender: str("male"), Age: age(48)},
+			"Игорь", 0.95, true,
+		},
+		{
+			"name matches case and yo insensitively",
+			model.Person{Surname: "Семёнов", Patronymic: str("Семёнович"), Age: age(30)},
+			model.Person{Name: "семен", Surname: "Семенов", Age: age(55)},
+			"Семён", 0.85, true,
+		},
+		{
+			"canonical name only",
+			model.Person{Surname: "Петров", Patronymic: str("Юрьевич"), Age: age(30)},
+			model.Person{Name: "Юра", Surname: "Петров", Gender: str("male"), Age: age(60)},
+			"Юрий", 0.85, true,
+		},
+		{
+			"unusual age gap",
+			model.Person{Surname: "Петров", Patronymic: str("Иванович"), Age: age(10)},
+			model.Person{Name: "Иван", Surname: "Петров", Gender: str("male"), Age: age(25)},
+			"Иван", 0.75, true,
+		},
+		{
+			"age unknown",
+			model.Person{Surname: "Петров", Patronymic: str("Иванович")},
+			model.Person{Name: "Иван", Surname: "Петров", Age: age(60)},
+			"Иван", 0.6, true,
+		},
+		{
+			"gap boundary 14",
+			model.Person{Surname: "Петров", Patronymic: str("Иванович"), Age: age(20)},
+			model.Person{Name: "Иван", Surname: "Петров", Age: age(34)},
+			"Иван", 0.65, true,
+		},
+		{
+			"too young",
+			model.Person{Surname: "Петров", Patronymic: str("Иванович"), Age: age(20)},
+			model.Person{Name: "Иван", Surname: "Петров", Gender: str("male"), Age: age(33)},
+			"Иван", 0, false,
+		},
+		{
+			"younger than child",
+			model.Person{Surname: "Петров", Patronymic: str("Иванович"), Age: age(40)},
+			model.Person{Name: "Иван", Surname: "Петров", Age: age(20)},
+			"Иван", 0, false,
+		},
+		{
+			"too old",
+			model.Person{Surname: "Петров", Patronymic: str("Иванович"), Age: age(10)},
+			model.Person{Name: "Иван", Surname: "Петров", Age: age(81)},
+			"Иван", 0, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := fatherSuggestion(&tt.person, &tt.candidate, tt.father)
+			if ok != tt.wantOK {
+				t.Fatalf("fatherSuggestion() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Score != tt.wantScore {
+				t.Errorf("fatherSuggestion() score = %v, want %v (%q)", got.Score, tt.wantScore, got.Reasons)
+			}
+			if got.Role != model.RelationParent || got.Person.Name != tt.candidate.Name {
+				t.Errorf("fatherSuggestion() = %+v, want parent %s", got, tt.candidate.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/pkg/translit"
+)
+
+// ErrMixedScript возвращается, когда в одном поле ФИО смешаны письменности
+var ErrMixedScript = errors.New("mixed scripts in name")
+
+// Политики обработки имён со смешанной письменностью
+const (
+	MixedScriptReject = "reject" // отклонять запрос (по умолчанию)
+	MixedScriptFlag   = "flag"   // сохранять с script = mixed
+)
+
+// nameField — поле ФИО для проверки письменности
+type nameField struct {
+	field string
+	value string
+}
+
+// personScript определяет письменность ФИО. Смешение письменностей внутри
+// одного поля (гомоглиф) отклоняется или помечается согласно политике;
+// разные письменности в разных полях дают mixed без ошибки.
+func (s *PersonService) personScript(name, surname string, patronymic *string) (string, error) {
+	fields := []nameField{{"name", name}, {"surname", surname}}
+	if patronymic != nil {
+		fields = append(fields, nameField{"patronymic", *patronymic})
+	}
+
+	result := ""
+	for _, f := range fields {
+		script := translit.DetectScript(f.value)
+		if script == translit.ScriptMixed && s.mixedScriptPolicy != MixedScriptFlag {
+			return "", fmt.Errorf("%w: %s %q", ErrMixedScript, f.field, f.value)
+		}
+		if script == translit.ScriptUnknown {
+			continue
+		}
+		if result == "" {
+			result = script
+		} else if result != script {
+			result = translit.ScriptMixed
+		}
+	}
+	if result == "" {
+		result = translit.ScriptUnknown
+	}
+	return result, nil
+}
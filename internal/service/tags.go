@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/evgeniySeleznev/person-enrichment-service/internal/model"
+)
+
+// ErrInvalidTag возвращается для тега, который не подходит под tagPattern
+var ErrInvalidTag = errors.New("invalid tag")
+
+// tagPattern — допустимый тег после приведения к нижнему регистру
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}._:-]{0,63}$`)
+
+// cleanTags обрезает пробелы, приводит теги к нижнему регистру, убирает пустые
+// и повторы; результат отсортирован
+func cleanTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// normalizeTags — cleanTags с проверкой формата тегов
+func normalizeTags(tags []string) ([]string, error) {
+	result := cleanTags(tags)
+	for _, tag := range result {
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("%w %q: expected letters, digits, '.', '_', ':' or '-', up to 64 characters", ErrInvalidTag, tag)
+		}
+	}
+	return result, nil
+}
+
+// cleanTagFilters нормализует теги в фильтрах так же, как при сохранении
+func cleanTagFilters(filterParams *model.FilterParams) {
+	filterParams.Tags = cleanTags(filterParams.Tags)
+	filterParams.TagsAny = cleanTags(filterParams.TagsAny)
+	filterParams.TagsNone = cleanTags(filterParams.TagsNone)
+}
+
+// AddTags добавляет теги человеку и возвращает его теги
+func (s *PersonService) AddTags(ctx context.Context, id int64, tags []string) (*model.PersonTags, error) {
+	add, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.personRepo.ChangeTags(ctx, id, add, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PersonTags{PersonID: id, Tags: current}, nil
+}
+
+// RemoveTag удаляет тег у человека и возвращает его теги; отсутствующий тег — не ошибка
+func (s *PersonService) RemoveTag(ctx context.Context, id int64, tag string) (*model.PersonTags, error) {
+	current, err := s.personRepo.ChangeTags(ctx, id, nil, cleanTags([]string{tag}))
+	if err != nil {
+		return nil, err
+	}
+	return &model.PersonTags{PersonID: id, Tags: current}, nil
+}
+
+// BulkTags добавляет и удаляет теги у набора людей
+func (s *PersonService) BulkTags(ctx context.Context, request model.BulkTagRequest) (*model.BulkTagResult, error) {
+	add, err := normalizeTags(request.Add)
+	if err != nil {
+		return nil, err
+	}
+	remove := cleanTags(request.Remove)
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, fmt.Errorf("%w: nothing to add or remove", ErrInvalidTag)
+	}
+	return s.personRepo.BulkChangeTags(ctx, request.PersonIDs, add, remove)
+}
+
+// TagCounts возвращает число людей с каждым тегом среди отфильтрованных
+func (s *PersonService) TagCounts(ctx context.Context, filterParams model.FilterParams) ([]model.TagCount, error) {
+	s.prepareFilter(&filterParams)
+	return s.personRepo.TagCounts(ctx, filterParams)
+}
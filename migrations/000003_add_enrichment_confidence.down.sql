@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_people_gender_probability;
+DROP INDEX IF EXISTS idx_people_nationality_probability;
+
+ALTER TABLE people
+    DROP COLUMN IF EXISTS age_count,
+    DROP COLUMN IF EXISTS gender_probability,
+    DROP COLUMN IF EXISTS gender_count,
+    DROP COLUMN IF EXISTS nationality_probability;
@@ -0,0 +1,8 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS age_count INTEGER,
+    ADD COLUMN IF NOT EXISTS gender_probability DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS gender_count INTEGER,
+    ADD COLUMN IF NOT EXISTS nationality_probability DOUBLE PRECISION;
+
+CREATE INDEX IF NOT EXISTS idx_people_gender_probability ON people(gender_probability);
+CREATE INDEX IF NOT EXISTS idx_people_nationality_probability ON people(nationality_probability);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_person_nationalities_country;
+DROP TABLE IF EXISTS person_nationalities;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS person_nationalities (
+                                      person_id BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+                                      country_id TEXT NOT NULL,
+                                      probability DOUBLE PRECISION NOT NULL,
+                                      rank SMALLINT NOT NULL,
+                                      PRIMARY KEY (person_id, rank)
+);
+
+CREATE INDEX IF NOT EXISTS idx_person_nationalities_country ON person_nationalities(country_id, probability);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_people_script;
+
+ALTER TABLE people DROP COLUMN IF EXISTS script;
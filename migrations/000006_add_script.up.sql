@@ -0,0 +1,3 @@
+ALTER TABLE people ADD COLUMN IF NOT EXISTS script TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_people_script ON people(script);
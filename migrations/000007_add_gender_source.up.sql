@@ -0,0 +1,3 @@
+ALTER TABLE people ADD COLUMN IF NOT EXISTS gender_source TEXT;
+
+UPDATE people SET gender_source = 'api' WHERE gender IS NOT NULL AND gender_source IS NULL;
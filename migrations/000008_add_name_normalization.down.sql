@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_people_canonical_name;
+
+ALTER TABLE people
+    DROP COLUMN IF EXISTS name_original,
+    DROP COLUMN IF EXISTS surname_original,
+    DROP COLUMN IF EXISTS patronymic_original,
+    DROP COLUMN IF EXISTS canonical_name;
@@ -0,0 +1,13 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS name_original TEXT,
+    ADD COLUMN IF NOT EXISTS surname_original TEXT,
+    ADD COLUMN IF NOT EXISTS patronymic_original TEXT,
+    ADD COLUMN IF NOT EXISTS canonical_name TEXT;
+
+UPDATE people SET
+    name_original = COALESCE(name_original, name),
+    surname_original = COALESCE(surname_original, surname),
+    patronymic_original = COALESCE(patronymic_original, patronymic),
+    canonical_name = COALESCE(canonical_name, name);
+
+CREATE INDEX IF NOT EXISTS idx_people_canonical_name ON people(canonical_name);
@@ -0,0 +1,11 @@
+ALTER TABLE people ADD COLUMN IF NOT EXISTS provenance JSONB NOT NULL DEFAULT '{}';
+
+-- Существующие значения получены до учёта происхождения: время неизвестно, берём момент миграции
+UPDATE people SET provenance = jsonb_strip_nulls(jsonb_build_object(
+    'age', CASE WHEN age IS NOT NULL
+        THEN jsonb_build_object('source', 'api:agify', 'updated_at', now()) END,
+    'gender', CASE WHEN gender IS NOT NULL
+        THEN jsonb_build_object('source', CASE gender_source WHEN 'rules' THEN 'rule' ELSE 'api:genderize' END, 'updated_at', now()) END,
+    'nationality', CASE WHEN nationality IS NOT NULL
+        THEN jsonb_build_object('source', 'api:nationalize', 'updated_at', now()) END
+)) WHERE provenance = '{}';
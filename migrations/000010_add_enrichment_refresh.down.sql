@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS enrichment_history;
+DROP INDEX IF EXISTS idx_people_enriched_at;
+ALTER TABLE people
+    DROP COLUMN IF EXISTS enrich_attempted_at,
+    DROP COLUMN IF EXISTS enriched_at;
@@ -0,0 +1,18 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS enriched_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS enrich_attempted_at TIMESTAMPTZ;
+
+-- Время обогащения существующих записей неизвестно: считаем их обогащёнными сейчас
+UPDATE people SET enriched_at = now() WHERE enriched_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_people_enriched_at ON people(enriched_at);
+
+CREATE TABLE IF NOT EXISTS enrichment_history (
+    id BIGSERIAL PRIMARY KEY,
+    person_id BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    enriched_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    triggered_by TEXT NOT NULL,
+    changes JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_enrichment_history_person ON enrichment_history(person_id, enriched_at DESC);
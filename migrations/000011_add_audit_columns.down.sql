@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_people_updated_at;
+DROP INDEX IF EXISTS idx_people_created_at;
+ALTER TABLE people
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
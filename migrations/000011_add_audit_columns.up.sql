@@ -0,0 +1,8 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS created_by TEXT,
+    ADD COLUMN IF NOT EXISTS updated_by TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_people_created_at ON people(created_at);
+CREATE INDEX IF NOT EXISTS idx_people_updated_at ON people(updated_at);
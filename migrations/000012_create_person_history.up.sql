@@ -0,0 +1,24 @@
+-- Без внешнего ключа на people: история должна переживать удаление записи
+CREATE TABLE IF NOT EXISTS person_history (
+    id BIGSERIAL PRIMARY KEY,
+    person_id BIGINT NOT NULL,
+    operation TEXT NOT NULL,
+    old_data JSONB,
+    new_data JSONB,
+    actor TEXT NOT NULL,
+    request_id TEXT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_person_history_person ON person_history(person_id, changed_at, id);
+
+-- Исходное состояние существующих записей, от которого отсчитывается история
+INSERT INTO person_history (person_id, operation, new_data, actor)
+SELECT p.person_id, 'snapshot',
+       (to_jsonb(p) - 'person_id' - 'enrich_attempted_at') || jsonb_build_object(
+           'id', p.person_id,
+           'nationalities', COALESCE((
+               SELECT jsonb_agg(jsonb_build_object('country_id', pn.country_id, 'probability', pn.probability, 'rank', pn.rank) ORDER BY pn.rank)
+               FROM person_nationalities pn WHERE pn.person_id = p.person_id), '[]'::jsonb)),
+       'migration'
+FROM people p;
@@ -0,0 +1,5 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_people_deleted_at ON people(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE people
+    DROP COLUMN IF EXISTS estimated_age_at,
+    DROP COLUMN IF EXISTS estimated_age,
+    DROP COLUMN IF EXISTS birth_year,
+    DROP COLUMN IF EXISTS birth_date;
@@ -0,0 +1,12 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS birth_date DATE,
+    ADD COLUMN IF NOT EXISTS birth_year SMALLINT,
+    ADD COLUMN IF NOT EXISTS estimated_age INT,
+    ADD COLUMN IF NOT EXISTS estimated_age_at TIMESTAMPTZ;
+
+-- Оценка agify, полученная до появления отдельного столбца
+UPDATE people SET
+    estimated_age = age,
+    estimated_age_at = COALESCE(enriched_at, created_at)
+WHERE age IS NOT NULL AND estimated_age IS NULL
+  AND COALESCE(provenance->'age'->>'source', 'api:agify') <> 'manual';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS attribute_schemas;
+DROP INDEX IF EXISTS idx_people_attributes;
+ALTER TABLE people DROP COLUMN IF EXISTS attributes;
@@ -0,0 +1,13 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+-- Фильтры attr.<ключ>=<значение> выполняются через attributes @> '{...}'
+CREATE INDEX IF NOT EXISTS idx_people_attributes ON people USING GIN (attributes jsonb_path_ops);
+
+-- JSON Schema атрибутов для каждого арендатора
+CREATE TABLE IF NOT EXISTS attribute_schemas (
+    tenant     TEXT PRIMARY KEY,
+    schema     JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by TEXT
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS person_tags (
+    person_id BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    tag       TEXT NOT NULL,
+    added_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    added_by  TEXT,
+    PRIMARY KEY (person_id, tag)
+);
+
+-- Фильтры tag/tag_any/tag_none и подсчёт по тегам
+CREATE INDEX IF NOT EXISTS idx_person_tags_tag ON person_tags(tag, person_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS person_addresses;
+DROP TABLE IF EXISTS person_phones;
+DROP TABLE IF EXISTS person_emails;
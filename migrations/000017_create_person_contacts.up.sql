@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS person_emails (
+    id         BIGSERIAL PRIMARY KEY,
+    person_id  BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    email      TEXT NOT NULL,
+    label      TEXT,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_emails_unique ON person_emails(person_id, lower(email));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_emails_primary ON person_emails(person_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS idx_person_emails_email ON person_emails(lower(email));
+
+-- Номера хранятся в формате E.164
+CREATE TABLE IF NOT EXISTS person_phones (
+    id         BIGSERIAL PRIMARY KEY,
+    person_id  BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    phone      TEXT NOT NULL CHECK (phone ~ '^\+[1-9][0-9]{7,14}$'),
+    label      TEXT,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_phones_unique ON person_phones(person_id, phone);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_phones_primary ON person_phones(person_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS idx_person_phones_phone ON person_phones(phone);
+
+CREATE TABLE IF NOT EXISTS person_addresses (
+    id          BIGSERIAL PRIMARY KEY,
+    person_id   BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    label       TEXT,
+    line1       TEXT NOT NULL,
+    line2       TEXT,
+    city        TEXT NOT NULL,
+    region      TEXT,
+    postal_code TEXT,
+    country     CHAR(2) NOT NULL,
+    is_primary  BOOLEAN NOT NULL DEFAULT false,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_person_addresses_person ON person_addresses(person_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_addresses_primary ON person_addresses(person_id) WHERE is_primary;
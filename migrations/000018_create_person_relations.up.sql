@@ -0,0 +1,17 @@
+-- Связи между людьми. parent и manager направлены: person_id — родитель
+-- (руководитель) related_id. spouse и sibling симметричны и хранятся один раз,
+-- с person_id < related_id.
+CREATE TABLE IF NOT EXISTS person_relations (
+    id         BIGSERIAL PRIMARY KEY,
+    person_id  BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    related_id BIGINT NOT NULL REFERENCES people(person_id) ON DELETE CASCADE,
+    type       TEXT NOT NULL CHECK (type IN ('parent', 'spouse', 'sibling', 'manager')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT,
+    CHECK (person_id <> related_id),
+    CHECK (type IN ('parent', 'manager') OR person_id < related_id),
+    UNIQUE (person_id, related_id, type)
+);
+
+-- Обход связей в обе стороны
+CREATE INDEX IF NOT EXISTS idx_person_relations_related ON person_relations(related_id);
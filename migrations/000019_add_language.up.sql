@@ -0,0 +1,3 @@
+-- Язык ФИО, заданный при создании; NULL — определяется по буквам ФИО
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS language TEXT CHECK (language IN ('ru', 'uk', 'be', 'kk'));
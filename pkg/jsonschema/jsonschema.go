@@ -0,0 +1,416 @@
+// Package jsonschema проверяет JSON-значения по подмножеству JSON Schema
+// (draft 2020-12): type, enum, const, properties, required, additionalProperties,
+// items, minItems/maxItems, minLength/maxLength, pattern, format,
+// minimum/maximum и exclusiveMinimum/exclusiveMaximum.
+//
+// Значения — результат encoding/json: map[string]interface{}, []interface{},
+// float64, string, bool и nil.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchema возвращается для схемы, которую нельзя скомпилировать
+var ErrInvalidSchema = errors.New("invalid JSON schema")
+
+// Типы JSON Schema
+var knownTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// Поддерживаемые значения format
+var formats = map[string]func(string) bool{
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"email": func(s string) bool {
+		address, err := mail.ParseAddress(s)
+		return err == nil && address.Address == s
+	},
+}
+
+// Ключевые слова-аннотации, которые не влияют на проверку
+var annotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "default": true, "examples": true, "deprecated": true,
+}
+
+// Schema — скомпилированная схема
+type Schema struct {
+	types []string
+	enum  []interface{}
+	cnst  *interface{}
+
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	noAdditional         bool
+
+	items              *Schema
+	minItems, maxItems *int
+
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	format               string
+
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+}
+
+// Compile разбирает схему из JSON. Неизвестные ключевые слова считаются ошибкой,
+// чтобы схема не выглядела строже, чем проверка на самом деле.
+func Compile(data []byte) (*Schema, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	return compile(raw, "#")
+}
+
+func compile(raw interface{}, path string) (*Schema, error) {
+	if allow, ok := raw.(bool); ok {
+		// true — любое значение, false — никакое
+		if allow {
+			return &Schema{}, nil
+		}
+		return &Schema{enum: []interface{}{}}, nil
+	}
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: schema must be an object or boolean", ErrInvalidSchema, path)
+	}
+
+	s := &Schema{}
+	invalid := func(keyword, format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s/%s: %s", ErrInvalidSchema, path, keyword, fmt.Sprintf(format, args...))
+	}
+
+	for _, keyword := range sortedKeys(object) {
+		value := object[keyword]
+		switch keyword {
+		case "type":
+			switch v := value.(type) {
+			case string:
+				s.types = []string{v}
+			case []interface{}:
+				for _, t := range v {
+					name, ok := t.(string)
+					if !ok {
+						return nil, invalid(keyword, "must be a string or an array of strings")
+					}
+					s.types = append(s.types, name)
+				}
+			default:
+				return nil, invalid(keyword, "must be a string or an array of strings")
+			}
+			for _, t := range s.types {
+				if !knownTypes[t] {
+					return nil, invalid(keyword, "unknown type %q", t)
+				}
+			}
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok {
+				return nil, invalid(keyword, "must be an array")
+			}
+			s.enum = values
+		case "const":
+			v := value
+			s.cnst = &v
+		case "properties":
+			properties, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, invalid(keyword, "must be an object")
+			}
+			s.properties = make(map[string]*Schema, len(properties))
+			for name, property := range properties {
+				compiled, err := compile(property, path+"/properties/"+name)
+				if err != nil {
+					return nil, err
+				}
+				s.properties[name] = compiled
+			}
+		case "required":
+			names, ok := value.([]interface{})
+			if !ok {
+				return nil, invalid(keyword, "must be an array of strings")
+			}
+			for _, n := range names {
+				name, ok := n.(string)
+				if !ok {
+					return nil, invalid(keyword, "must be an array of strings")
+				}
+				s.required = append(s.required, name)
+			}
+		case "additionalProperties":
+			if allow, ok := value.(bool); ok {
+				s.noAdditional = !allow
+				continue
+			}
+			compiled, err := compile(value, path+"/additionalProperties")
+			if err != nil {
+				return nil, err
+			}
+			s.additionalProperties = compiled
+		case "items":
+			compiled, err := compile(value, path+"/items")
+			if err != nil {
+				return nil, err
+			}
+			s.items = compiled
+		case "minItems", "maxItems", "minLength", "maxLength":
+			n, ok := nonNegativeInt(value)
+			if !ok {
+				return nil, invalid(keyword, "must be a non-negative integer")
+			}
+			switch keyword {
+			case "minItems":
+				s.minItems = &n
+			case "maxItems":
+				s.maxItems = &n
+			case "minLength":
+				s.minLength = &n
+			case "maxLength":
+				s.maxLength = &n
+			}
+		case "pattern":
+			expr, ok := value.(string)
+			if !ok {
+				return nil, invalid(keyword, "must be a string")
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, invalid(keyword, "%v", err)
+			}
+			s.pattern = re
+		case "format":
+			name, ok := value.(string)
+			if !ok || formats[name] == nil {
+				return nil, invalid(keyword, "unsupported format %v", value)
+			}
+			s.format = name
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			n, ok := value.(float64)
+			if !ok {
+				return nil, invalid(keyword, "must be a number")
+			}
+			switch keyword {
+			case "minimum":
+				s.minimum = &n
+			case "maximum":
+				s.maximum = &n
+			case "exclusiveMinimum":
+				s.exclusiveMinimum = &n
+			case "exclusiveMaximum":
+				s.exclusiveMaximum = &n
+			}
+		default:
+			if !annotations[keyword] {
+				return nil, invalid(keyword, "unsupported keyword")
+			}
+		}
+	}
+	return s, nil
+}
+
+// FieldError — нарушение схемы в одном месте значения
+type FieldError struct {
+	// Path — путь в формате JSON Pointer, "" — корень значения
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError перечисляет все нарушения схемы
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		path := fe.Path
+		if path == "" {
+			path = "/"
+		}
+		messages[i] = path + ": " + fe.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate проверяет value по схеме. Возвращает *ValidationError со всеми нарушениями или nil.
+func (s *Schema) Validate(value interface{}) error {
+	var errs []FieldError
+	s.validate(value, "", &errs)
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func (s *Schema) validate(value interface{}, path string, errs *[]FieldError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.types) > 0 && !s.matchesType(value) {
+		fail("expected %s, got %s", strings.Join(s.types, " or "), typeOf(value))
+		return
+	}
+	if s.enum != nil && !contains(s.enum, value) {
+		if len(s.enum) == 0 {
+			fail("no value is allowed")
+		} else {
+			fail("must be one of %s", encode(s.enum))
+		}
+	}
+	if s.cnst != nil && !equal(*s.cnst, value) {
+		fail("must be %s", encode(*s.cnst))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, FieldError{Path: path + "/" + escape(name), Message: "is required"})
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			child := path + "/" + escape(name)
+			if property, ok := s.properties[name]; ok {
+				property.validate(v[name], child, errs)
+			} else if s.noAdditional {
+				*errs = append(*errs, FieldError{Path: child, Message: "is not allowed"})
+			} else if s.additionalProperties != nil {
+				s.additionalProperties.validate(v[name], child, errs)
+			}
+		}
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			fail("must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			fail("must have at most %d items", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, fmt.Sprintf("%s/%d", path, i), errs)
+			}
+		}
+	case string:
+		length := len([]rune(v))
+		if s.minLength != nil && length < *s.minLength {
+			fail("must be at least %d characters long", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			fail("must be at most %d characters long", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %q", s.pattern.String())
+		}
+		if s.format != "" && !formats[s.format](v) {
+			fail("must be a valid %s", s.format)
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			fail("must be >= %v", *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			fail("must be <= %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			fail("must be > %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			fail("must be < %v", *s.exclusiveMaximum)
+		}
+	}
+}
+
+func (s *Schema) matchesType(value interface{}) bool {
+	actual := typeOf(value)
+	for _, t := range s.types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf возвращает тип JSON Schema значения; целые числа — integer
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func encode(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func nonNegativeInt(value interface{}) (int, bool) {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// escape экранирует имя свойства для JSON Pointer (RFC 6901)
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
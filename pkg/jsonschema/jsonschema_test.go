@@ -0,0 +1,176 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		// Пути нарушений в порядке обнаружения; nil — значение корректно
+		want []string
+	}{
+		// type
+		{"type string ok", `{"type":"string"}`, `"x"`, nil},
+		{"type string mismatch", `{"type":"string"}`, `1`, []string{""}},
+		{"type integer ok", `{"type":"integer"}`, `3`, nil},
+		{"type integer fraction", `{"type":"integer"}`, `3.5`, []string{""}},
+		{"type number accepts integer", `{"type":"number"}`, `3`, nil},
+		{"type null", `{"type":"null"}`, `null`, nil},
+		{"type union", `{"type":["string","null"]}`, `null`, nil},
+		{"type union mismatch", `{"type":["string","null"]}`, `true`, []string{""}},
+
+		// required
+		{"required present", `{"required":["a"]}`, `{"a":1}`, nil},
+		{"required missing", `{"required":["a","b"]}`, `{"b":1}`, []string{"/a"}},
+		{"required ignores non-object", `{"required":["a"]}`, `"x"`, nil},
+
+		// properties
+		{"properties ok", `{"properties":{"age":{"type":"integer"}}}`, `{"age":30}`, nil},
+		{"properties mismatch", `{"properties":{"age":{"type":"integer"}}}`, `{"age":"30"}`, []string{"/age"}},
+		{"properties nested", `{"properties":{"a":{"properties":{"b":{"type":"string"}}}}}`, `{"a":{"b":1}}`, []string{"/a/b"}},
+		{"properties escaped path", `{"properties":{"a/b":{"type":"string"}}}`, `{"a/b":1}`, []string{"/a~1b"}},
+
+		// enum и const
+		{"enum ok", `{"enum":["sales","support"]}`, `"sales"`, nil},
+		{"enum mismatch", `{"enum":["sales","support"]}`, `"hr"`, []string{""}},
+		{"enum mixed types", `{"enum":[1,"1",null]}`, `null`, nil},
+		{"const mismatch", `{"const":{"a":1}}`, `{"a":2}`, []string{""}},
+
+		// minimum / maximum
+		{"minimum equal", `{"minimum":18}`, `18`, nil},
+		{"minimum below", `{"minimum":18}`, `17`, []string{""}},
+		{"maximum above", `{"maximum":100}`, `100.5`, []string{""}},
+		{"exclusiveMinimum equal", `{"exclusiveMinimum":0}`, `0`, []string{""}},
+		{"exclusiveMaximum below", `{"exclusiveMaximum":1}`, `0.99`, nil},
+
+		// minLength / maxLength считают символы, а не байты
+		{"minLength cyrillic", `{"minLength":4}`, `"Иван"`, nil},
+		{"maxLength cyrillic", `{"maxLength":3}`, `"Иван"`, []string{""}},
+
+		// minItems / maxItems / items
+		{"minItems", `{"minItems":2}`, `[1]`, []string{""}},
+		{"maxItems", `{"maxItems":1}`, `[1,2]`, []string{""}},
+		{"items", `{"items":{"type":"string"}}`, `["a",2,"c",4]`, []string{"/1", "/3"}},
+
+		// pattern не привязан к началу строки без ^
+		{"pattern ok", `{"pattern":"^[A-Z]{2}$"}`, `"RU"`, nil},
+		{"pattern mismatch", `{"pattern":"^[A-Z]{2}$"}`, `"RUS"`, []string{""}},
+		{"pattern unanchored", `{"pattern":"[0-9]"}`, `"ab1"`, nil},
+
+		// format
+		{"format date", `{"format":"date"}`, `"1990-05-17"`, nil},
+		{"format date invalid", `{"format":"date"}`, `"1990-02-30"`, []string{""}},
+		{"format date-time", `{"format":"date-time"}`, `"2024-01-02T03:04:05Z"`, nil},
+		{"format email", `{"format":"email"}`, `"ivan@example.com"`, nil},
+		{"format email with name", `{"format":"email"}`, `"Ivan <ivan@example.com>"`, []string{""}},
+
+		// additionalProperties
+		{"additionalProperties false", `{"properties":{"a":{}},"additionalProperties":false}`, `{"a":1,"b":2,"c":3}`, []string{"/b", "/c"}},
+		{"additionalProperties true", `{"properties":{"a":{}},"additionalProperties":true}`, `{"b":2}`, nil},
+		{"additionalProperties schema", `{"additionalProperties":{"type":"string"}}`, `{"a":"x","b":1}`, []string{"/b"}},
+
+		// Булевы схемы
+		{"true schema", `true`, `{"any":[1]}`, nil},
+		{"false schema", `false`, `1`, []string{""}},
+		{"false property", `{"properties":{"secret":false}}`, `{"secret":"x"}`, []string{"/secret"}},
+
+		// Аннотации не влияют на проверку
+		{"annotations", `{"title":"t","description":"d","default":1,"type":"integer"}`, `2`, nil},
+
+		// Все нарушения собираются, а не только первое
+		{
+			"collects all errors",
+			`{"type":"object","required":["department"],"properties":{"level":{"type":"integer","minimum":1}},"additionalProperties":false}`,
+			`{"level":0,"extra":true}`,
+			[]string{"/department", "/extra", "/level"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Compile([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("Compile(%s) error = %v", tt.schema, err)
+			}
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatal(err)
+			}
+
+			err = schema.Validate(value)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate(%s) error = %v, want nil", tt.value, err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate(%s) error = %v, want *ValidationError", tt.value, err)
+			}
+			got := make([]string, len(verr.Errors))
+			for i, fe := range verr.Errors {
+				got[i] = fe.Path
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) paths = %q, want %q (%v)", tt.value, got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not json", `{`},
+		{"not object", `"string"`},
+		{"unknown type", `{"type":"text"}`},
+		{"type not string", `{"type":1}`},
+		{"enum not array", `{"enum":"a"}`},
+		{"properties not object", `{"properties":[]}`},
+		{"nested invalid property", `{"properties":{"a":{"type":"text"}}}`},
+		{"required not strings", `{"required":[1]}`},
+		{"negative minLength", `{"minLength":-1}`},
+		{"fractional maxItems", `{"maxItems":1.5}`},
+		{"minimum not number", `{"minimum":"1"}`},
+		{"bad pattern", `{"pattern":"("}`},
+		{"unsupported format", `{"format":"uuid"}`},
+		{"unsupported keyword", `{"oneOf":[]}`},
+		{"typo in keyword", `{"requried":["a"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile([]byte(tt.schema)); !errors.Is(err, ErrInvalidSchema) {
+				t.Errorf("Compile(%s) error = %v, want %v", tt.schema, err, ErrInvalidSchema)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	schema, err := Compile([]byte(`{"type":"object","required":["a"],"properties":{"b":{"type":"string"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = schema.Validate(map[string]interface{}{"b": 1.0})
+	want := "/a: is required; /b: expected string, got integer"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %q", err, want)
+	}
+
+	err = schema.Validate("x")
+	want = "/: expected object, got string"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %q", err, want)
+	}
+}
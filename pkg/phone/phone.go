@@ -0,0 +1,111 @@
+// Package phone приводит телефонные номера к формату E.164 (+<код страны><номер>).
+//
+// Полных метаданных номеров по странам пакет не содержит: проверяется только
+// общая длина (8–15 цифр) и код страны. Номер без международного префикса
+// считается национальным номером страны по умолчанию.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidNumber возвращается для строки, которую нельзя привести к E.164
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+// Длина E.164 без «+»
+const (
+	minDigits = 8
+	maxDigits = 15
+)
+
+// trunkPrefixes — внутренний префикс междугородней связи, если он не «0»
+var trunkPrefixes = map[string]string{
+	"7":   "8",  // Россия, Казахстан
+	"375": "80", // Беларусь
+}
+
+// nationalLengths — длина национального номера для кодов, где номер без «+»
+// часто записывают вместе с кодом страны (79161234567)
+var nationalLengths = map[string]int{
+	"1":   10,
+	"7":   10,
+	"375": 9,
+	"380": 9,
+}
+
+// ValidCountryCode сообщает, подходит ли code как код страны по умолчанию
+func ValidCountryCode(code string) bool {
+	if len(code) == 0 || len(code) > 3 || code[0] == '0' {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalize приводит номер к E.164. Допускаются цифры, пробелы, «-», «.», скобки
+// и ведущий «+» или «00». Номер с внутренним префиксом (8 для кода 7, иначе 0)
+// или без префикса дополняется кодом страны defaultCode.
+func Normalize(raw, defaultCode string) (string, error) {
+	value := strings.TrimSpace(raw)
+	international := false
+	switch {
+	case strings.HasPrefix(value, "+"):
+		international = true
+		value = value[1:]
+	case strings.HasPrefix(value, "00"):
+		international = true
+		value = value[2:]
+	}
+
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("%w: unexpected character %q", ErrInvalidNumber, r)
+		}
+	}
+	number := digits.String()
+
+	if !international {
+		if !ValidCountryCode(defaultCode) {
+			return "", fmt.Errorf("%w: country code is required", ErrInvalidNumber)
+		}
+		number = withCountryCode(number, defaultCode)
+	}
+
+	if len(number) < minDigits || len(number) > maxDigits {
+		return "", fmt.Errorf("%w: expected %d to %d digits including country code", ErrInvalidNumber, minDigits, maxDigits)
+	}
+	if number[0] == '0' {
+		return "", fmt.Errorf("%w: country code cannot start with 0", ErrInvalidNumber)
+	}
+	return "+" + number, nil
+}
+
+// withCountryCode превращает национальную запись номера в международную
+func withCountryCode(number, code string) string {
+	trunk := "0"
+	if prefix, ok := trunkPrefixes[code]; ok {
+		trunk = prefix
+	}
+	length, known := nationalLengths[code]
+
+	switch {
+	case known && len(number) == len(code)+length && strings.HasPrefix(number, code):
+		// Код страны уже записан, но без «+»
+		return number
+	case strings.HasPrefix(number, trunk) && (!known || len(number) == len(trunk)+length):
+		return code + number[len(trunk):]
+	default:
+		return code + number
+	}
+}
@@ -0,0 +1,90 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		defaultCode string
+		want        string
+		wantErr     bool
+	}{
+		// Россия и Казахстан: внутренний префикс 8
+		{"ru trunk prefix formatted", "8 (916) 123-45-67", "7", "+79161234567", false},
+		{"ru trunk prefix digits", "89161234567", "7", "+79161234567", false},
+		{"ru code without plus", "79161234567", "7", "+79161234567", false},
+		{"ru international", "+7 916 123-45-67", "7", "+79161234567", false},
+		{"ru national without prefix", "9161234567", "7", "+79161234567", false},
+		{"kz trunk prefix", "8 (701) 123-45-67", "7", "+77011234567", false},
+
+		// Беларусь: внутренний префикс 80
+		{"by trunk prefix", "80291234567", "375", "+375291234567", false},
+		{"by trunk prefix formatted", "8 029 123-45-67", "375", "+375291234567", false},
+		{"by code without plus", "375291234567", "375", "+375291234567", false},
+
+		// Украина: внутренний префикс 0
+		{"ua trunk prefix", "050 123 45 67", "380", "+380501234567", false},
+
+		// США: без внутреннего префикса, код страны часто пишут без «+»
+		{"us code without plus", "1 212 555 0123", "1", "+12125550123", false},
+		{"us national", "(212) 555-0123", "1", "+12125550123", false},
+
+		// Международный формат не зависит от кода по умолчанию
+		{"international plus", "+44 20 7946 0958", "7", "+442079460958", false},
+		{"international 00", "0044 20 7946 0958", "7", "+442079460958", false},
+		{"dots", "+49.30.123456", "7", "+4930123456", false},
+		{"surrounding spaces", "  +79161234567 ", "7", "+79161234567", false},
+
+		// Ошибки
+		{"letters", "8 916 ABC-45-67", "7", "", true},
+		{"extension", "+7 916 123-45-67 ext 5", "7", "", true},
+		{"too short", "12345", "7", "", true},
+		{"too short international", "+7123", "7", "", true},
+		{"too long", "+1234567890123456", "7", "", true},
+		{"country code zero", "+0123456789", "7", "", true},
+		{"empty", "", "7", "", true},
+		{"national without default code", "9161234567", "", "", true},
+		{"invalid default code", "9161234567", "07", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.raw, tt.defaultCode)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNumber) {
+					t.Errorf("Normalize(%q, %q) = %q, %v; want %v", tt.raw, tt.defaultCode, got, err, ErrInvalidNumber)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Normalize(%q, %q) = %q, %v; want %q", tt.raw, tt.defaultCode, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidCountryCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"7", true},
+		{"375", true},
+		{"1", true},
+		{"", false},
+		{"0", false},
+		{"1234", false},
+		{"+7", false},
+		{"7a", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidCountryCode(tt.code); got != tt.want {
+			t.Errorf("ValidCountryCode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
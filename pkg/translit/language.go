@@ -0,0 +1,193 @@
+package translit
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Языки, для которых есть национальные схемы латинизации
+const (
+	LangRussian    = "ru"
+	LangUkrainian  = "uk"
+	LangBelarusian = "be"
+	LangKazakh     = "kk"
+)
+
+// nationalSchemes — официальные национальные схемы латинизации по языкам
+var nationalSchemes = map[string]string{
+	LangRussian:    "icao",
+	LangUkrainian:  "uk-kmu2010",
+	LangBelarusian: "be-2007",
+	LangKazakh:     "kk-2021",
+}
+
+// ForLanguage возвращает официальную национальную схему для языка
+func ForLanguage(lang string) (*Scheme, error) {
+	name, ok := nationalSchemes[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w for language %q", ErrUnknownScheme, lang)
+	}
+	return Lookup(name)
+}
+
+// DetectLanguage определяет язык кириллического текста по характерным буквам.
+// Если таких букв нет, текст считается русским.
+func DetectLanguage(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.ContainsAny(lower, "әғқңөұүһ"):
+		return LangKazakh
+	case strings.ContainsRune(lower, 'ў'):
+		return LangBelarusian
+	case strings.ContainsAny(lower, "їєґ"):
+		return LangUkrainian
+	case strings.ContainsRune(lower, 'і'):
+		// «і» есть и в украинском, и в белорусском; ы, э и ё — только в белорусском
+		if strings.ContainsAny(lower, "ыэё") {
+			return LangBelarusian
+		}
+		return LangUkrainian
+	case strings.ContainsAny(lower, "'’ʼ") && !strings.ContainsAny(lower, "ыэёъ"):
+		// Апостроф вместо твёрдого знака: «П'ятниця»
+		return LangUkrainian
+	}
+	return LangRussian
+}
+
+// FoldASCII убирает диакритику из латиницы: «Mahilioŭ» → «Mahiliou», «Şolpan» → «Solpan».
+// Внешние API обогащения ожидают имена в ASCII.
+func FoldASCII(text string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'ı':
+			builder.WriteRune('i')
+		case r == 'ʼ' || r == 'ˮ' || r == 'ʹ' || r == 'ʺ' || r == '`':
+			continue
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// isApostrophe — апостроф в украинском и белорусском письме
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// isNationalWordStart — начало слова без учёта апострофа внутри слова
+func isNationalWordStart(prev rune) bool {
+	return isWordStart(prev) && !isApostrophe(prev)
+}
+
+func init() {
+	// Украинский: постановление КМУ № 55 от 27.01.2010
+	register(&Scheme{
+		Name:        "uk-kmu2010",
+		Description: "Украинский, постановление Кабмина Украины № 55 (2010)",
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g",
+			'д': "d", 'е': "e", 'є': "ie", 'ж': "zh", 'з': "z",
+			'и': "y", 'і': "i", 'ї': "i", 'й': "i", 'к': "k",
+			'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p",
+			'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+			'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+			'ь': "", 'ю': "iu", 'я': "ia",
+			'\'': "", '’': "", 'ʼ': "",
+		},
+		rule: func(prev, cur, next rune) (string, bool) {
+			// «зг» передаётся как «zgh», чтобы отличать от «ж»
+			if cur == 'г' && prev == 'з' {
+				return "gh", true
+			}
+			if !isNationalWordStart(prev) {
+				return "", false
+			}
+			// Йотированные в начале слова
+			switch cur {
+			case 'є':
+				return "ye", true
+			case 'ї':
+				return "yi", true
+			case 'й':
+				return "y", true
+			case 'ю':
+				return "yu", true
+			case 'я':
+				return "ya", true
+			}
+			return "", false
+		},
+	})
+
+	// Белорусский: национальная система 2007 года, принята ООН
+	register(&Scheme{
+		Name:        "be-2007",
+		Description: "Белорусский, национальная система латинизации (2007)",
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "h", 'д': "d",
+			'е': "ie", 'ё': "io", 'ж': "ž", 'з': "z", 'і': "i",
+			'й': "j", 'к': "k", 'л': "l", 'м': "m", 'н': "n",
+			'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ў': "ŭ", 'ф': "f", 'х': "ch", 'ц': "c",
+			'ч': "č", 'ш': "š", 'ы': "y", 'ь': "", 'э': "e",
+			'ю': "iu", 'я': "ia",
+			'\'': "", '’': "", 'ʼ': "",
+		},
+		rule: func(prev, cur, next rune) (string, bool) {
+			// Мягкий знак смягчает предыдущую согласную: зь → ź, ль → ĺ и т.д.
+			if next == 'ь' {
+				switch cur {
+				case 'з':
+					return "ź", true
+				case 'л':
+					return "ĺ", true
+				case 'н':
+					return "ń", true
+				case 'с':
+					return "ś", true
+				case 'ц':
+					return "ć", true
+				}
+			}
+			// je, jo, ju, ja в начале слова, после гласных, апострофа, ь и ў
+			if isNationalWordStart(prev) || isVowel(prev) || isApostrophe(prev) || prev == 'ь' || prev == 'ў' {
+				switch cur {
+				case 'е':
+					return "je", true
+				case 'ё':
+					return "jo", true
+				case 'ю':
+					return "ju", true
+				case 'я':
+					return "ja", true
+				}
+			}
+			return "", false
+		},
+	})
+
+	// Казахский: латинский алфавит, утверждённый в 2021 году
+	register(&Scheme{
+		Name:        "kk-2021",
+		Description: "Казахский, латинский алфавит (2021)",
+		caseMap:     unicode.TurkishCase, // i ↔ İ, ı ↔ I
+		table: map[rune]string{
+			'а': "a", 'ә': "ä", 'б': "b", 'в': "v", 'г': "g",
+			'ғ': "ğ", 'д': "d", 'е': "e", 'ё': "io", 'ж': "j",
+			'з': "z", 'и': "i", 'й': "i", 'к': "k", 'қ': "q",
+			'л': "l", 'м': "m", 'н': "n", 'ң': "ñ", 'о': "o",
+			'ө': "ö", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ұ': "ū", 'ү': "ü", 'ф': "f", 'х': "h",
+			'һ': "h", 'ц': "ts", 'ч': "ch", 'ш': "ş", 'щ': "şş",
+			'ъ': "", 'ы': "y", 'і': "ı", 'ь': "", 'э': "e",
+			'ю': "iu", 'я': "ia",
+		},
+	})
+}
@@ -0,0 +1,130 @@
+package translit
+
+import "unicode"
+
+// isVowel — гласные кириллицы (в нижнем регистре) для контекстных правил
+func isVowel(r rune) bool {
+	switch r {
+	case 'а', 'е', 'ё', 'и', 'о', 'у', 'ы', 'э', 'ю', 'я', 'і', 'ї', 'є':
+		return true
+	}
+	return false
+}
+
+// isWordStart сообщает, что предыдущий символ не буква кириллицы
+func isWordStart(prev rune) bool {
+	return prev == 0 || !unicode.Is(unicode.Cyrillic, prev)
+}
+
+func init() {
+	// Историческая таблица сервиса
+	register(&Scheme{
+		Name:        "simple",
+		Description: "Упрощённая транслитерация, использовавшаяся сервисом исторически",
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g",
+			'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+			'з': "z", 'и': "i", 'й': "y", 'к': "k",
+			'л': "l", 'м': "m", 'н': "n", 'о': "o",
+			'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+			'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+			'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+			'я': "ya",
+		},
+	})
+
+	// ISO 9:1995 (ГОСТ 7.79-2000, система А) — однозначная и обратимая
+	register(&Scheme{
+		Name:        "iso9",
+		Description: "ISO 9:1995 / ГОСТ 7.79-2000 система А, обратимая, с диакритикой",
+		Reversible:  true,
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g",
+			'д': "d", 'е': "e", 'ё': "ë", 'ж': "ž",
+			'з': "z", 'и': "i", 'й': "j", 'к': "k",
+			'л': "l", 'м': "m", 'н': "n", 'о': "o",
+			'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ф': "f", 'х': "h", 'ц': "c",
+			'ч': "č", 'ш': "š", 'щ': "ŝ", 'ъ': "ʺ",
+			'ы': "y", 'ь': "ʹ", 'э': "è", 'ю': "û",
+			'я': "â",
+			// украинские и белорусские буквы
+			'і': "ì", 'ї': "ï", 'є': "ê", 'ґ': "g̀", 'ў': "ǔ",
+		},
+	})
+
+	// ГОСТ 7.79-2000, система Б — только ASCII
+	register(&Scheme{
+		Name:        "gost779",
+		Description: "ГОСТ 7.79-2000 система Б, без диакритики",
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g",
+			'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+			'з': "z", 'и': "i", 'й': "j", 'к': "k",
+			'л': "l", 'м': "m", 'н': "n", 'о': "o",
+			'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ф': "f", 'х': "x", 'ц': "cz",
+			'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "``",
+			'ы': "y`", 'ь': "`", 'э': "e`", 'ю': "yu",
+			'я': "ya",
+		},
+		rule: func(prev, cur, next rune) (string, bool) {
+			// «ц» перед и, е, ы, й передаётся как «c»
+			if cur == 'ц' {
+				switch next {
+				case 'и', 'е', 'ы', 'й':
+					return "c", true
+				}
+			}
+			return "", false
+		},
+	})
+
+	// ICAO Doc 9303 — загранпаспорта РФ с 2013 года
+	register(&Scheme{
+		Name:        "icao",
+		Description: "ICAO Doc 9303, как в загранпаспортах РФ",
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g",
+			'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+			'з': "z", 'и': "i", 'й': "i", 'к': "k",
+			'л': "l", 'м': "m", 'н': "n", 'о': "o",
+			'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+			'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ie",
+			'ы': "y", 'ь': "", 'э': "e", 'ю': "iu",
+			'я': "ia",
+		},
+	})
+
+	// BGN/PCGN 1947 — используется в архиве
+	register(&Scheme{
+		Name:        "bgn",
+		Description: "BGN/PCGN 1947",
+		table: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g",
+			'д': "d", 'е': "e", 'ё': "ë", 'ж': "zh",
+			'з': "z", 'и': "i", 'й': "y", 'к': "k",
+			'л': "l", 'м': "m", 'н': "n", 'о': "o",
+			'п': "p", 'р': "r", 'с': "s", 'т': "t",
+			'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+			'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ˮ",
+			'ы': "y", 'ь': "ʼ", 'э': "e", 'ю': "yu",
+			'я': "ya",
+		},
+		rule: func(prev, cur, next rune) (string, bool) {
+			// «е» и «ё» в начале слова, после гласных, й, ъ и ь — «ye» и «yë»
+			if cur != 'е' && cur != 'ё' {
+				return "", false
+			}
+			if isWordStart(prev) || isVowel(prev) || prev == 'й' || prev == 'ъ' || prev == 'ь' {
+				if cur == 'е' {
+					return "ye", true
+				}
+				return "yë", true
+			}
+			return "", false
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package translit
+
+import "unicode"
+
+// Письменности, которые различает DetectScript
+const (
+	ScriptCyrillic = "cyrillic"
+	ScriptLatin    = "latin"
+	ScriptGreek    = "greek"
+	ScriptGeorgian = "georgian"
+	ScriptArmenian = "armenian"
+	ScriptOther    = "other"
+	ScriptMixed    = "mixed"
+	ScriptUnknown  = "unknown"
+)
+
+var scriptTables = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{ScriptCyrillic, unicode.Cyrillic},
+	{ScriptLatin, unicode.Latin},
+	{ScriptGreek, unicode.Greek},
+	{ScriptGeorgian, unicode.Georgian},
+	{ScriptArmenian, unicode.Armenian},
+}
+
+// DetectScript определяет письменность текста по буквам. Пробелы, дефисы,
+// апострофы (в том числе буквенные «ʼ» U+02BC и «ʹ» U+02B9) и диакритические
+// знаки не учитываются. Буквы нескольких
+// письменностей (например, латинская «a» в кириллическом имени) дают ScriptMixed.
+func DetectScript(text string) string {
+	found := ""
+	for _, r := range text {
+		if !unicode.IsLetter(r) || isNeutralModifier(r) {
+			continue
+		}
+		script := ScriptOther
+		for _, s := range scriptTables {
+			if unicode.Is(s.table, r) {
+				script = s.name
+				break
+			}
+		}
+		if found == "" {
+			found = script
+		} else if found != script {
+			return ScriptMixed
+		}
+	}
+	if found == "" {
+		return ScriptUnknown
+	}
+	return found
+}
+
+// isNeutralModifier — буквы-модификаторы без своей письменности: украинский апостроф
+// «ʼ», знаки мягкости и твёрдости ISO 9 «ʹ» и «ʺ». Как и разделители, они
+// не говорят о письменности имени.
+func isNeutralModifier(r rune) bool {
+	return unicode.Is(unicode.Lm, r) && unicode.Is(unicode.Common, r)
+}
@@ -0,0 +1,156 @@
+// Package translit реализует транслитерацию кириллицы латиницей по именованным схемам
+// (ISO 9, ГОСТ 7.79, ICAO Doc 9303, BGN/PCGN и др.)
+package translit
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultScheme — схема, которую сервис исторически использовал для запросов к API
+const DefaultScheme = "simple"
+
+var (
+	// ErrUnknownScheme возвращается при запросе незарегистрированной схемы
+	ErrUnknownScheme = errors.New("unknown transliteration scheme")
+	// ErrNotReversible возвращается при попытке обратной транслитерации по необратимой схеме
+	ErrNotReversible = errors.New("transliteration scheme is not reversible")
+)
+
+// Scheme — именованная схема транслитерации
+type Scheme struct {
+	Name        string
+	Description string
+	Reversible  bool
+
+	// table отображает строчные буквы кириллицы в строчную латиницу
+	table map[rune]string
+	// caseMap — особые правила регистра (например, турецкие i/İ и ı/I)
+	caseMap unicode.SpecialCase
+	// rule — контекстные правила поверх table; prev и next уже в нижнем регистре
+	// (0 на границе строки). ok=false — использовать table.
+	rule func(prev, cur, next rune) (out string, ok bool)
+
+	reverse       map[string]rune
+	reverseMaxLen int
+}
+
+var schemes = map[string]*Scheme{}
+
+func register(s *Scheme) {
+	if s.Reversible {
+		s.reverse = make(map[string]rune, len(s.table))
+		for cyr, lat := range s.table {
+			s.reverse[lat] = cyr
+			if n := utf8.RuneCountInString(lat); n > s.reverseMaxLen {
+				s.reverseMaxLen = n
+			}
+		}
+	}
+	schemes[s.Name] = s
+}
+
+// Lookup возвращает схему по имени
+func Lookup(name string) (*Scheme, error) {
+	s, ok := schemes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q (available: %s)", ErrUnknownScheme, name, strings.Join(Names(), ", "))
+	}
+	return s, nil
+}
+
+// Names возвращает отсортированный список доступных схем
+func Names() []string {
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Transliterate переводит текст в латиницу. Символы вне схемы остаются как есть.
+// Заглавная буква даёт «Shch» перед строчной и «SHCH» в остальных случаях.
+func (s *Scheme) Transliterate(text string) string {
+	runes := []rune(text)
+	var builder strings.Builder
+	for i, r := range runes {
+		var prev, next rune
+		if i > 0 {
+			prev = unicode.ToLower(runes[i-1])
+		}
+		if i+1 < len(runes) {
+			next = unicode.ToLower(runes[i+1])
+		}
+
+		lower := unicode.ToLower(r)
+		out, ok := "", false
+		if s.rule != nil {
+			out, ok = s.rule(prev, lower, next)
+		}
+		if !ok {
+			out, ok = s.table[lower]
+		}
+		if !ok {
+			builder.WriteRune(r)
+			continue
+		}
+
+		if unicode.IsUpper(r) {
+			titleCase := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			out = s.applyCase(out, titleCase)
+		}
+		builder.WriteString(out)
+	}
+	return builder.String()
+}
+
+// Reverse восстанавливает кириллицу из латиницы для обратимых схем
+func (s *Scheme) Reverse(text string) (string, error) {
+	if !s.Reversible {
+		return "", fmt.Errorf("%w: %s", ErrNotReversible, s.Name)
+	}
+
+	runes := []rune(norm.NFC.String(text))
+	var builder strings.Builder
+	for i := 0; i < len(runes); {
+		matched := false
+		// Самое длинное совпадение (например, «g̀» из двух символов)
+		for n := min(s.reverseMaxLen, len(runes)-i); n > 0; n-- {
+			chunk := strings.ToLower(string(runes[i : i+n]))
+			cyr, ok := s.reverse[chunk]
+			if !ok {
+				continue
+			}
+			if unicode.IsUpper(runes[i]) {
+				cyr = unicode.ToUpper(cyr)
+			}
+			builder.WriteRune(cyr)
+			i += n
+			matched = true
+			break
+		}
+		if !matched {
+			builder.WriteRune(runes[i])
+			i++
+		}
+	}
+	return builder.String(), nil
+}
+
+func (s *Scheme) applyCase(out string, titleCase bool) string {
+	if !titleCase {
+		return strings.ToUpperSpecial(s.caseMap, out)
+	}
+	first, size := utf8.DecodeRuneInString(out)
+	if first == utf8.RuneError {
+		return out
+	}
+	return string(s.caseMap.ToUpper(first)) + out[size:]
+}
@@ -0,0 +1,220 @@
+package translit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		scheme string
+		in     string
+		want   string
+	}{
+		// ISO 9:1995 / ГОСТ 7.79-2000 система А
+		{"iso9", "Щукин", "Ŝukin"},
+		{"iso9", "Юлия", "Ûliâ"},
+		{"iso9", "Ёлкин", "Ëlkin"},
+		{"iso9", "Ярослав", "Âroslav"},
+		{"iso9", "Подъячев", "Podʺâčev"},
+		{"iso9", "Игорь", "Igorʹ"},
+		{"iso9", "Эдуард Цой", "Èduard Coj"},
+
+		// ГОСТ 7.79-2000 система Б
+		{"gost779", "Щукин", "Shhukin"},
+		{"gost779", "Юлия", "Yuliya"},
+		{"gost779", "Ёлкин", "Yolkin"},
+		{"gost779", "Ярослав", "Yaroslav"},
+		{"gost779", "Цапля", "Czaplya"},
+		{"gost779", "Цимбал", "Cimbal"},
+		{"gost779", "Подъячев", "Pod``yachev"},
+		{"gost779", "Эльвира", "E`l`vira"},
+		{"gost779", "Быстров", "By`strov"},
+		{"gost779", "Хабаров", "Xabarov"},
+
+		// ICAO Doc 9303
+		{"icao", "Щукин", "Shchukin"},
+		{"icao", "Юлия", "Iuliia"},
+		{"icao", "Ёлкин", "Elkin"},
+		{"icao", "Ярослав", "Iaroslav"},
+		{"icao", "Подъячев", "Podieiachev"},
+		{"icao", "Андрей", "Andrei"},
+		{"icao", "ЩУКИН", "SHCHUKIN"},
+
+		// BGN/PCGN 1947
+		{"bgn", "Щукин", "Shchukin"},
+		{"bgn", "Юлия", "Yuliya"},
+		{"bgn", "Ёлкин", "Yëlkin"},
+		{"bgn", "Ярослав", "Yaroslav"},
+		{"bgn", "Елена", "Yelena"},
+		{"bgn", "Андреев", "Andreyev"},
+		{"bgn", "Игорь", "Igorʼ"},
+
+		// Историческая схема сервиса
+		{"simple", "Щукин", "Shchukin"},
+		{"simple", "Юлия", "Yuliya"},
+		{"simple", "Ёлкин", "Yolkin"},
+		{"simple", "Ярослав", "Yaroslav"},
+		{"simple", "Татьяна", "Tatyana"},
+
+		// Украинский, КМУ № 55 (примеры из постановления)
+		{"uk-kmu2010", "Згорани", "Zghorany"},
+		{"uk-kmu2010", "Єнакієве", "Yenakiieve"},
+		{"uk-kmu2010", "Гаєвич", "Haievych"},
+		{"uk-kmu2010", "Короп'є", "Koropie"},
+		{"uk-kmu2010", "Юрій", "Yurii"},
+		{"uk-kmu2010", "Крюківка", "Kriukivka"},
+		{"uk-kmu2010", "Їжакевич", "Yizhakevych"},
+		{"uk-kmu2010", "Кадиївка", "Kadyivka"},
+		{"uk-kmu2010", "Йосипівка", "Yosypivka"},
+		{"uk-kmu2010", "Олексій", "Oleksii"},
+		{"uk-kmu2010", "Щербухи", "Shcherbukhy"},
+		{"uk-kmu2010", "Ґалаґан", "Galagan"},
+		{"uk-kmu2010", "Марія", "Mariia"},
+
+		// Белорусский, национальная система 2007
+		{"be-2007", "Магілёў", "Mahilioŭ"},
+		{"be-2007", "Віцебск", "Viciebsk"},
+		{"be-2007", "Гомель", "Homieĺ"},
+		{"be-2007", "Ельск", "Jeĺsk"},
+		{"be-2007", "Заслаўе", "Zaslaŭje"},
+		{"be-2007", "Шчучын", "Ščučyn"},
+		{"be-2007", "Лагойск", "Lahojsk"},
+
+		// Казахский, алфавит 2021
+		{"kk-2021", "Қазақстан", "Qazaqstan"},
+		{"kk-2021", "Нұрсұлтан", "Nūrsūltan"},
+		{"kk-2021", "Әлихан", "Älihan"},
+		{"kk-2021", "Шолпан", "Şolpan"},
+		{"kk-2021", "Ғалым", "Ğalym"},
+		{"kk-2021", "Өскемен", "Öskemen"},
+		{"kk-2021", "Ирина", "İrina"},
+		{"kk-2021", "Ілияс", "Iliias"},
+
+		// Символы вне схемы остаются как есть
+		{"icao", "Анна-Мария O'Neil", "Anna-Mariia O'Neil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme+"/"+tt.in, func(t *testing.T) {
+			scheme, err := Lookup(tt.scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := scheme.Transliterate(tt.in); got != tt.want {
+				t.Errorf("Transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	iso9, err := Lookup("iso9")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"Щукин", "Юлия", "Ёлкин", "Ярослав", "Подъячев", "Игорь", "Ґалаґан", "Ўладзімір"} {
+		latin := iso9.Transliterate(word)
+		got, err := iso9.Reverse(latin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != word {
+			t.Errorf("Reverse(%q) = %q, want %q", latin, got, word)
+		}
+	}
+
+	icao, err := Lookup("icao")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := icao.Reverse("Shchukin"); !errors.Is(err, ErrNotReversible) {
+		t.Errorf("icao Reverse() error = %v, want %v", err, ErrNotReversible)
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, err := Lookup("klingon"); !errors.Is(err, ErrUnknownScheme) {
+		t.Errorf("Lookup() error = %v, want %v", err, ErrUnknownScheme)
+	}
+	if _, err := ForLanguage("pl"); !errors.Is(err, ErrUnknownScheme) {
+		t.Errorf("ForLanguage() error = %v, want %v", err, ErrUnknownScheme)
+	}
+}
+
+func TestDetectScript(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Иван", ScriptCyrillic},
+		{"Анна-Мария", ScriptCyrillic},
+		{"Ivan", ScriptLatin},
+		{"O'Neil", ScriptLatin},
+		{"Ξενοφών", ScriptGreek},
+		{"გიორგი", ScriptGeorgian},
+		{"Արամ", ScriptArmenian},
+		{"太郎", ScriptOther},
+		{"Иvan", ScriptMixed},
+		{"Ивaн", ScriptMixed},       // латинская «a»
+		{"Марʼяна", ScriptCyrillic}, // украинский апостроф U+02BC
+		{"Igorʹ", ScriptLatin},      // ISO 9: мягкий знак U+02B9
+		{"Podʺâčev", ScriptLatin},   // ISO 9: твёрдый знак U+02BA
+		{"ʼ", ScriptUnknown},
+		{"", ScriptUnknown},
+		{" - ", ScriptUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := DetectScript(tt.in); got != tt.want {
+			t.Errorf("DetectScript(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Иван Петров", LangRussian},
+		{"Подъячев", LangRussian},
+		{"Олена Їжакевич", LangUkrainian},
+		{"Іван Франко", LangUkrainian},
+		{"Мар'яна", LangUkrainian},
+		{"Ґалаґан", LangUkrainian},
+		{"Ўладзімір", LangBelarusian},
+		{"Ігар Ёлкін", LangBelarusian},
+		{"Нұрсұлтан", LangKazakh},
+		{"Әлихан", LangKazakh},
+	}
+
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.in); got != tt.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFoldASCII(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Mahilioŭ", "Mahiliou"},
+		{"Şolpan", "Solpan"},
+		{"Nūrsūltan", "Nursultan"},
+		{"İrina", "Irina"},
+		{"Ilıas", "Ilias"},
+		{"Igorʼ", "Igor"},
+		{"Ŝukin", "Sukin"},
+		{"Ivan", "Ivan"},
+	}
+
+	for _, tt := range tests {
+		if got := FoldASCII(tt.in); got != tt.want {
+			t.Errorf("FoldASCII(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}